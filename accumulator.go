@@ -0,0 +1,135 @@
+package mlscratchlib
+
+import "math"
+
+// Accumulator computes descriptive statistics of a stream of values one
+// value at a time, without keeping the values around. It uses Welford's
+// online update for the mean and the central moment sums, extended to
+// the third and fourth moments by Terriberry and Pébay. The zero value
+// is an empty accumulator ready to use
+type Accumulator struct {
+	n    float64
+	mean float64
+	m2   float64 // sum of squared deviations from the mean
+	m3   float64 // sum of cubed deviations from the mean
+	m4   float64 // sum of deviations from the mean to the fourth power
+	min  float64
+	max  float64
+}
+
+// Add adds a single value to the accumulator
+func (a *Accumulator) Add(x float64) {
+	if a.n == 0 {
+		a.min, a.max = x, x
+	} else {
+		a.min = math.Min(a.min, x)
+		a.max = math.Max(a.max, x)
+	}
+
+	n1 := a.n
+	a.n++
+	n := a.n
+	delta := x - a.mean
+	deltaN := delta / n
+	deltaN2 := deltaN * deltaN
+	term1 := delta * deltaN * n1
+
+	// the higher moments have to be updated before the lower ones they use
+	a.mean += deltaN
+	a.m4 += term1*deltaN2*(n*n-3*n+3) + 6*deltaN2*a.m2 - 4*deltaN*a.m3
+	a.m3 += term1*deltaN*(n-2) - 3*deltaN*a.m2
+	a.m2 += term1
+}
+
+// AddValues adds every element of vector to the accumulator
+func (a *Accumulator) AddValues(vector []float64) {
+	for _, element := range vector {
+		a.Add(element)
+	}
+}
+
+// Merge combines the values seen by other into a, as if every value
+// added to other had been added to a. This lets parallel workers each
+// keep their own accumulator and combine them at the end
+func (a *Accumulator) Merge(other *Accumulator) {
+	if other.n == 0 {
+		return
+	}
+	if a.n == 0 {
+		*a = *other
+		return
+	}
+
+	na, nb := a.n, other.n
+	n := na + nb
+	delta := other.mean - a.mean
+	delta2 := delta * delta
+
+	mean := a.mean + delta*nb/n
+	m2 := a.m2 + other.m2 + delta2*na*nb/n
+	m3 := a.m3 + other.m3 + delta2*delta*na*nb*(na-nb)/(n*n) +
+		3*delta*(na*other.m2-nb*a.m2)/n
+	m4 := a.m4 + other.m4 + delta2*delta2*na*nb*(na*na-na*nb+nb*nb)/(n*n*n) +
+		6*delta2*(na*na*other.m2+nb*nb*a.m2)/(n*n) +
+		4*delta*(na*other.m3-nb*a.m3)/n
+
+	a.n, a.mean, a.m2, a.m3, a.m4 = n, mean, m2, m3, m4
+	a.min = math.Min(a.min, other.min)
+	a.max = math.Max(a.max, other.max)
+}
+
+// Count returns the number of values added
+func (a *Accumulator) Count() int {
+	return int(a.n)
+}
+
+// Mean returns the mean of the values added, or 0 if there are none
+// like VectorMean
+func (a *Accumulator) Mean() float64 {
+	return a.mean
+}
+
+// Variance returns the sample variance of the values added, dividing by
+// the count minus one like VarianceVector. It returns 0 for fewer than
+// 2 values
+func (a *Accumulator) Variance() float64 {
+	if a.n < 2 {
+		return 0
+	}
+	return a.m2 / (a.n - 1)
+}
+
+// StandardDeviation returns the square root of the sample variance
+func (a *Accumulator) StandardDeviation() float64 {
+	return math.Sqrt(a.Variance())
+}
+
+// Min returns the smallest value added, or 0 if there are none
+func (a *Accumulator) Min() float64 {
+	return a.min
+}
+
+// Max returns the largest value added, or 0 if there are none
+func (a *Accumulator) Max() float64 {
+	return a.max
+}
+
+// Skewness returns the population skewness g1 = m3 / m2^(3/2) of the
+// values added, where mk is the kth central moment. It is 0 for a
+// symmetric distribution and NaN when every value is the same
+func (a *Accumulator) Skewness() float64 {
+	if a.n < 1 {
+		return math.NaN()
+	}
+	return math.Sqrt(a.n) * a.m3 / math.Pow(a.m2, 1.5)
+}
+
+// Kurtosis returns the population excess kurtosis g2 = m4 / m2^2 - 3 of
+// the values added, where mk is the kth central moment. It is 0 for a
+// normal distribution and NaN when every value is the same
+func (a *Accumulator) Kurtosis() float64 {
+	if a.n < 1 {
+		return math.NaN()
+	}
+	return a.n*a.m4/(a.m2*a.m2) - 3
+}
@@ -0,0 +1,98 @@
+package mlscratchlib
+
+import (
+	"math"
+	"testing"
+)
+
+// twoPassMoments returns the population skewness and excess kurtosis of
+// vector computed directly from the de-meaned values
+func twoPassMoments(vector []float64) (skewness float64, kurtosis float64) {
+	var m2, m3, m4 float64
+	for _, deviation := range DeMeanVector(vector) {
+		m2 += deviation * deviation
+		m3 += deviation * deviation * deviation
+		m4 += deviation * deviation * deviation * deviation
+	}
+	n := float64(len(vector))
+	m2, m3, m4 = m2/n, m3/n, m4/n
+	return m3 / math.Pow(m2, 1.5), m4/(m2*m2) - 3
+}
+
+func TestAccumulator(t *testing.T) {
+	var a Accumulator
+	a.AddValues(vec8c)
+
+	if a.Count() != len(vec8c) {
+		t.Errorf("Expected count of %d, got %d", len(vec8c), a.Count())
+	}
+
+	if !almostEqual(a.Mean(), VectorMean(vec8c), 1e-10) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", VectorMean(vec8c), a.Mean())
+	}
+
+	if !almostEqual(a.Variance(), VarianceVector(vec8c), 1e-8) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", VarianceVector(vec8c), a.Variance())
+	}
+
+	if !almostEqual(a.StandardDeviation(), StandardDeviationVector(vec8c), 1e-10) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", StandardDeviationVector(vec8c), a.StandardDeviation())
+	}
+
+	if a.Min() != 0.01 || a.Max() != 1000 {
+		t.Errorf("Expected min 0.01 and max 1000, got %v and %v", a.Min(), a.Max())
+	}
+
+	skewness, kurtosis := twoPassMoments(vec8c)
+
+	if !almostEqual(a.Skewness(), skewness, 1e-10) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", skewness, a.Skewness())
+	}
+
+	if !almostEqual(a.Kurtosis(), kurtosis, 1e-10) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", kurtosis, a.Kurtosis())
+	}
+
+	// a symmetric vector has no skew
+	var symmetric Accumulator
+	symmetric.AddValues(vec8b)
+
+	if !almostEqual(symmetric.Skewness(), 0, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", 0, symmetric.Skewness())
+	}
+
+	var empty Accumulator
+
+	if empty.Count() != 0 || empty.Mean() != 0 || empty.Variance() != 0 || !math.IsNaN(empty.Skewness()) {
+		t.Errorf("Unexpected statistics for an empty accumulator")
+	}
+}
+
+func TestAccumulatorMerge(t *testing.T) {
+	var all, first, second, third Accumulator
+	all.AddValues(vec8a)
+	all.AddValues(vec8c)
+	all.AddValues(vec10a)
+
+	first.AddValues(vec8a)
+	second.AddValues(vec8c)
+	third.AddValues(vec10a)
+
+	var merged Accumulator
+	merged.Merge(&first) // merging into an empty accumulator copies it
+	merged.Merge(&second)
+	merged.Merge(&third)
+	merged.Merge(&Accumulator{}) // merging an empty accumulator changes nothing
+
+	if merged.Count() != all.Count() || merged.Min() != all.Min() || merged.Max() != all.Max() {
+		t.Errorf("Expected count %d min %v max %v, got %d %v %v",
+			all.Count(), all.Min(), all.Max(), merged.Count(), merged.Min(), merged.Max())
+	}
+
+	expected := []float64{all.Mean(), all.Variance(), all.Skewness(), all.Kurtosis()}
+	result := []float64{merged.Mean(), merged.Variance(), merged.Skewness(), merged.Kurtosis()}
+
+	if !vectorsAlmostEqual(result, expected, 1e-8) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+}
@@ -0,0 +1,115 @@
+package mlscratchlib
+
+import (
+	"errors"
+	"math"
+)
+
+// Cholesky holds the Cholesky factorization of a symmetric positive
+// definite matrix A so that A = L * L^T, where L is lower triangular
+// with a positive diagonal
+type Cholesky struct {
+	l *Matrix
+}
+
+// CholeskyDecompose accepts a symmetric positive definite matrix, such as
+// a covariance matrix, and returns its Cholesky factorization. It returns
+// an error if the matrix is not square, not symmetric or not positive
+// definite
+func CholeskyDecompose(a *Matrix) (*Cholesky, error) {
+	if a.rows != a.cols {
+		return nil, errors.New("the matrix must be square")
+	}
+	n := a.rows
+	l := NewMatrix(n, n)
+	for j := 0; j < n; j++ {
+		var diagonal float64
+		for k := 0; k < j; k++ {
+			diagonal += l.data[j*n+k] * l.data[j*n+k]
+		}
+		diagonal = a.data[j*n+j] - diagonal
+		if diagonal <= 0 || math.IsNaN(diagonal) {
+			return nil, errors.New("the matrix is not positive definite")
+		}
+		l.data[j*n+j] = math.Sqrt(diagonal)
+
+		for i := j + 1; i < n; i++ {
+			if !isClose(a.data[i*n+j], a.data[j*n+i]) {
+				return nil, errors.New("the matrix must be symmetric")
+			}
+			var sum float64
+			for k := 0; k < j; k++ {
+				sum += l.data[i*n+k] * l.data[j*n+k]
+			}
+			l.data[i*n+j] = (a.data[i*n+j] - sum) / l.data[j*n+j]
+		}
+	}
+	return &Cholesky{l: l}, nil
+}
+
+// L returns the lower triangular factor
+func (c *Cholesky) L() *Matrix {
+	return c.l.Copy()
+}
+
+// Solve accepts a vector b and returns the vector x that solves A*x = b
+// by forward substitution with L followed by back substitution with L^T
+func (c *Cholesky) Solve(b []float64) ([]float64, error) {
+	n := c.l.rows
+	if len(b) != n {
+		return nil, errors.New("the vector must have one element for every row of the matrix")
+	}
+
+	// forward substitution to solve L*y = b
+	x := make([]float64, n)
+	for i := 0; i < n; i++ {
+		sum := b[i]
+		for k := 0; k < i; k++ {
+			sum -= c.l.data[i*n+k] * x[k]
+		}
+		x[i] = sum / c.l.data[i*n+i]
+	}
+
+	// back substitution to solve L^T*x = y
+	for i := n - 1; i >= 0; i-- {
+		sum := x[i]
+		for k := i + 1; k < n; k++ {
+			sum -= c.l.data[k*n+i] * x[k]
+		}
+		x[i] = sum / c.l.data[i*n+i]
+	}
+	return x, nil
+}
+
+// LogDeterminant returns the natural log of the determinant of A, which
+// is twice the sum of the logs of the diagonal of L. Working in logs
+// avoids the overflow and underflow that the determinant itself hits
+// for large covariance matrices
+func (c *Cholesky) LogDeterminant() float64 {
+	n := c.l.rows
+	var sum float64
+	for i := 0; i < n; i++ {
+		sum += math.Log(c.l.data[i*n+i])
+	}
+	return 2 * sum
+}
+
+// CholeskySolve accepts a symmetric positive definite matrix a and a
+// vector b and returns the vector x that solves a*x = b
+func CholeskySolve(a *Matrix, b []float64) ([]float64, error) {
+	c, err := CholeskyDecompose(a)
+	if err != nil {
+		return nil, err
+	}
+	return c.Solve(b)
+}
+
+// LogDeterminant accepts a symmetric positive definite matrix and
+// returns the natural log of its determinant
+func LogDeterminant(a *Matrix) (float64, error) {
+	c, err := CholeskyDecompose(a)
+	if err != nil {
+		return 0, err
+	}
+	return c.LogDeterminant(), nil
+}
@@ -0,0 +1,87 @@
+package mlscratchlib
+
+import (
+	"math"
+	"testing"
+)
+
+// sample symmetric positive definite matrix for testing
+var spd3a = [][]float64{{4, 12, -16}, {12, 37, -43}, {-16, -43, 98}}
+
+func TestCholeskyDecompose(t *testing.T) {
+	c, err := CholeskyDecompose(mustMatrix(t, spd3a))
+	expected := [][]float64{{2, 0, 0}, {6, 1, 0}, {-8, 5, 3}}
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !matricesAlmostEqual(c.L(), mustMatrix(t, expected), 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, c.L().ToSlices())
+	}
+
+	c, err = CholeskyDecompose(mustMatrix(t, [][]float64{{1, 2}, {2, 1}})) // test the indefinite logic
+
+	if err == nil || c != nil {
+		t.Errorf("Function accepted a matrix that is not positive definite")
+	}
+
+	c, err = CholeskyDecompose(mustMatrix(t, [][]float64{{4, 1}, {2, 4}})) // test the non-symmetric logic
+
+	if err == nil || c != nil {
+		t.Errorf("Function accepted a non-symmetric matrix")
+	}
+
+	c, err = CholeskyDecompose(mustMatrix(t, matrix2x3)) // test the non-square logic
+
+	if err == nil || c != nil {
+		t.Errorf("Function accepted a non-square matrix")
+	}
+}
+
+func TestCholeskySolve(t *testing.T) {
+	var err error
+	var expected, result []float64
+
+	a := mustMatrix(t, spd3a)
+	expected = []float64{1, -2, 3}
+	b, _ := a.MulVector(expected)
+	result, err = CholeskySolve(a, b)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !vectorsAlmostEqual(result, expected, 1e-10) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	result, err = CholeskySolve(a, vec8a) // test the length mismatch logic
+
+	if err == nil || result != nil {
+		t.Errorf("Function accepted a vector of the wrong length, got %v", result)
+	}
+}
+
+func TestLogDeterminant(t *testing.T) {
+	var err error
+	var expected, result float64
+
+	result, err = LogDeterminant(mustMatrix(t, spd3a))
+	expected = math.Log(36) // (2 * 1 * 3) squared
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	result, err = LogDeterminant(Identity(5))
+	expected = 0
+
+	if result != expected {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+}
@@ -0,0 +1,501 @@
+package mlscratchlib
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// DiscreteDistribution is a probability distribution over the integers
+// with fixed parameters, the discrete counterpart of Distribution.
+// Quantile returns the smallest k whose CDF is at least p. Rand draws
+// from rng, or from the global source in math/rand when rng is nil.
+// Where Distribution returns NaN, Quantile and Rand return
+// InvalidQuantile, which is outside the support of every distribution
+// here. That is the case for a p outside [0, 1] and, along with NaN from
+// the other methods, for parameters outside their valid range
+type DiscreteDistribution interface {
+	PMF(k int) float64
+	LogPMF(k int) float64
+	CDF(k int) float64
+	Quantile(p float64) int
+	Mean() float64
+	Variance() float64
+	Rand(rng *rand.Rand) int
+}
+
+// xLogY returns x * log(y), treating 0 * log(0) as 0 so that probabilities
+// of 0 or 1 don't produce NaN
+func xLogY(x float64, y float64) float64 {
+	if x == 0 {
+		return 0
+	}
+	return x * math.Log(y)
+}
+
+// InvalidQuantile is returned by the Quantile and Rand methods of a
+// DiscreteDistribution in place of the NaN that Distribution returns
+const InvalidQuantile = -1
+
+// isProbability reports whether p is in [0, 1]
+func isProbability(p float64) bool {
+	return p >= 0 && p <= 1
+}
+
+// discreteQuantile returns the smallest k in [low, high] whose cdf is at
+// least probability, walking down and then up from guess. A good guess,
+// such as one from a normal approximation, keeps the walk short
+func discreteQuantile(cdf func(int) float64, probability float64, guess int, low int, high int) int {
+	if guess < low {
+		guess = low
+	} else if guess > high {
+		guess = high
+	}
+	for guess > low && cdf(guess-1) >= probability {
+		guess--
+	}
+	for guess < high && cdf(guess) < probability {
+		guess++
+	}
+	return guess
+}
+
+// normalGuess returns the p quantile of a normal distribution with the
+// given mean and variance, rounded down and clamped to the int range, as a
+// starting point for discreteQuantile
+func normalGuess(p float64, mean float64, variance float64) int {
+	guess := math.Floor(mean + math.Sqrt(variance)*InverseNormalCDF(p, 0, 1, 1e-6))
+	if !(guess < math.MaxInt32) {
+		return math.MaxInt32
+	} else if guess < 0 {
+		return 0
+	}
+	return int(guess)
+}
+
+// Bernoulli is the distribution of a single trial that is 1 with
+// probability P and 0 otherwise
+type Bernoulli struct {
+	P float64
+}
+
+// valid reports whether P is a probability
+func (b Bernoulli) valid() bool {
+	return isProbability(b.P)
+}
+
+// PMF returns the probability of k
+func (b Bernoulli) PMF(k int) float64 {
+	if !b.valid() {
+		return math.NaN()
+	}
+	switch k {
+	case 0:
+		return 1 - b.P
+	case 1:
+		return b.P
+	}
+	return 0
+}
+
+// LogPMF returns the log of the probability of k
+func (b Bernoulli) LogPMF(k int) float64 {
+	return math.Log(b.PMF(k))
+}
+
+// CDF returns the probability of a number less than or equal to k
+func (b Bernoulli) CDF(k int) float64 {
+	if !b.valid() {
+		return math.NaN()
+	} else if k < 0 {
+		return 0
+	} else if k < 1 {
+		return 1 - b.P
+	}
+	return 1
+}
+
+// Quantile returns the smallest k whose CDF is at least p
+func (b Bernoulli) Quantile(p float64) int {
+	if !isProbability(p) || !b.valid() {
+		return InvalidQuantile
+	} else if p <= 1-b.P {
+		return 0
+	}
+	return 1
+}
+
+// Mean returns P
+func (b Bernoulli) Mean() float64 {
+	if !b.valid() {
+		return math.NaN()
+	}
+	return b.P
+}
+
+// Variance returns P * (1 - P)
+func (b Bernoulli) Variance() float64 {
+	if !b.valid() {
+		return math.NaN()
+	}
+	return b.P * (1 - b.P)
+}
+
+// Rand returns 1 with probability P and 0 otherwise
+func (b Bernoulli) Rand(rng *rand.Rand) int {
+	if !b.valid() {
+		return InvalidQuantile
+	} else if randFloat64(rng) < b.P {
+		return 1
+	}
+	return 0
+}
+
+// Binomial is the distribution of the number of successes in N independent
+// trials that each succeed with probability P, such as the number of heads
+// in N coin flips
+type Binomial struct {
+	N int
+	P float64
+}
+
+// valid reports whether N is at least 0 and P is a probability
+func (b Binomial) valid() bool {
+	return b.N >= 0 && isProbability(b.P)
+}
+
+// PMF returns the probability of exactly k successes
+func (b Binomial) PMF(k int) float64 {
+	return math.Exp(b.LogPMF(k))
+}
+
+// LogPMF returns the log of the probability of exactly k successes,
+// computed with log-gamma functions so that large N doesn't overflow
+func (b Binomial) LogPMF(k int) float64 {
+	if !b.valid() {
+		return math.NaN()
+	} else if k < 0 || k > b.N {
+		return math.Inf(-1)
+	}
+	lgN, _ := math.Lgamma(float64(b.N + 1))
+	lgK, _ := math.Lgamma(float64(k + 1))
+	lgRest, _ := math.Lgamma(float64(b.N - k + 1))
+	return lgN - lgK - lgRest + xLogY(float64(k), b.P) + xLogY(float64(b.N-k), 1-b.P)
+}
+
+// CDF returns the probability of k or fewer successes, using the
+// regularized incomplete beta function I_(1-P)(N - k, k + 1) rather than
+// summing the PMF
+func (b Binomial) CDF(k int) float64 {
+	if !b.valid() {
+		return math.NaN()
+	} else if k < 0 {
+		return 0
+	} else if k >= b.N {
+		return 1
+	}
+	return regularizedIncompleteBeta(float64(b.N-k), float64(k+1), 1-b.P)
+}
+
+// Quantile returns the smallest k whose CDF is at least p
+func (b Binomial) Quantile(p float64) int {
+	if !isProbability(p) || !b.valid() {
+		return InvalidQuantile
+	} else if p == 0 {
+		return 0
+	} else if p == 1 {
+		return b.N
+	}
+	return discreteQuantile(b.CDF, p, normalGuess(p, b.Mean(), b.Variance()), 0, b.N)
+}
+
+// Mean returns N * P
+func (b Binomial) Mean() float64 {
+	if !b.valid() {
+		return math.NaN()
+	}
+	return float64(b.N) * b.P
+}
+
+// Variance returns N * P * (1 - P)
+func (b Binomial) Variance() float64 {
+	if !b.valid() {
+		return math.NaN()
+	}
+	return float64(b.N) * b.P * (1 - b.P)
+}
+
+// Rand returns a random number of successes by inverse transform sampling
+func (b Binomial) Rand(rng *rand.Rand) int {
+	return b.Quantile(randFloat64(rng))
+}
+
+// NormalApproximationCDF returns the normal approximation to the
+// probability of k or fewer successes, NormalCDF(k + 0.5, N*P,
+// sqrt(N*P*(1-P))) with a continuity correction of 0.5. The approximation
+// is reasonable when N*P and N*(1-P) are both at least 5 or so
+func (b Binomial) NormalApproximationCDF(k int) float64 {
+	return NormalCDF(float64(k)+0.5, b.Mean(), math.Sqrt(b.Variance()))
+}
+
+// Poisson is the distribution of the number of events in an interval when
+// events happen independently at an average rate of Lambda per interval
+type Poisson struct {
+	Lambda float64
+}
+
+// valid reports whether Lambda is finite and at least 0
+func (p Poisson) valid() bool {
+	return p.Lambda >= 0 && !math.IsInf(p.Lambda, 1)
+}
+
+// PMF returns the probability of exactly k events
+func (p Poisson) PMF(k int) float64 {
+	return math.Exp(p.LogPMF(k))
+}
+
+// LogPMF returns the log of the probability of exactly k events
+func (p Poisson) LogPMF(k int) float64 {
+	if !p.valid() {
+		return math.NaN()
+	} else if k < 0 {
+		return math.Inf(-1)
+	}
+	lgK, _ := math.Lgamma(float64(k + 1))
+	return xLogY(float64(k), p.Lambda) - p.Lambda - lgK
+}
+
+// CDF returns the probability of k or fewer events, using the regularized
+// incomplete gamma function 1 - P(k + 1, Lambda)
+func (p Poisson) CDF(k int) float64 {
+	if !p.valid() {
+		return math.NaN()
+	} else if k < 0 {
+		return 0
+	}
+	return 1 - regularizedLowerIncompleteGamma(float64(k+1), p.Lambda)
+}
+
+// Quantile returns the smallest k whose CDF is at least probability. A
+// probability of 1 returns math.MaxInt32 since the support is unbounded
+func (p Poisson) Quantile(probability float64) int {
+	if !isProbability(probability) || !p.valid() {
+		return InvalidQuantile
+	} else if probability == 0 {
+		return 0
+	} else if probability == 1 {
+		return math.MaxInt32
+	}
+	return discreteQuantile(p.CDF, probability, normalGuess(probability, p.Lambda, p.Lambda), 0, math.MaxInt32)
+}
+
+// Mean returns Lambda
+func (p Poisson) Mean() float64 {
+	if !p.valid() {
+		return math.NaN()
+	}
+	return p.Lambda
+}
+
+// Variance returns Lambda
+func (p Poisson) Variance() float64 {
+	if !p.valid() {
+		return math.NaN()
+	}
+	return p.Lambda
+}
+
+// Rand returns a random number of events by inverse transform sampling
+func (p Poisson) Rand(rng *rand.Rand) int {
+	return p.Quantile(randFloat64(rng))
+}
+
+// Geometric is the distribution of the number of trials up to and
+// including the first success, when each trial succeeds with probability
+// P, so its support starts at 1
+type Geometric struct {
+	P float64
+}
+
+// valid reports whether P is in (0, 1], a P of 0 never succeeds
+func (g Geometric) valid() bool {
+	return g.P > 0 && g.P <= 1
+}
+
+// PMF returns the probability that the first success is on trial k
+func (g Geometric) PMF(k int) float64 {
+	if !g.valid() {
+		return math.NaN()
+	} else if k < 1 {
+		return 0
+	}
+	return math.Pow(1-g.P, float64(k-1)) * g.P
+}
+
+// LogPMF returns the log of the probability that the first success is on
+// trial k
+func (g Geometric) LogPMF(k int) float64 {
+	if !g.valid() {
+		return math.NaN()
+	} else if k < 1 {
+		return math.Inf(-1)
+	}
+	return xLogY(float64(k-1), 1-g.P) + math.Log(g.P)
+}
+
+// CDF returns the probability that the first success is on or before
+// trial k, 1 - (1 - P)^k
+func (g Geometric) CDF(k int) float64 {
+	if !g.valid() {
+		return math.NaN()
+	} else if k < 1 {
+		return 0
+	}
+	return -math.Expm1(float64(k) * math.Log1p(-g.P))
+}
+
+// Quantile returns the smallest k whose CDF is at least p. A p of 1
+// returns math.MaxInt32 since the support is unbounded
+func (g Geometric) Quantile(p float64) int {
+	if !isProbability(p) || !g.valid() {
+		return InvalidQuantile
+	} else if p == 0 {
+		return 1
+	} else if p == 1 {
+		return math.MaxInt32
+	}
+	// the closed form can be off by one from rounding, which the walk fixes
+	guess := math.Ceil(math.Log1p(-p) / math.Log1p(-g.P))
+	if !(guess < math.MaxInt32) {
+		guess = math.MaxInt32
+	}
+	return discreteQuantile(g.CDF, p, int(guess), 1, math.MaxInt32)
+}
+
+// Mean returns 1 / P
+func (g Geometric) Mean() float64 {
+	if !g.valid() {
+		return math.NaN()
+	}
+	return 1 / g.P
+}
+
+// Variance returns (1 - P) / P^2
+func (g Geometric) Variance() float64 {
+	if !g.valid() {
+		return math.NaN()
+	}
+	return (1 - g.P) / (g.P * g.P)
+}
+
+// Rand returns a random number of trials by inverse transform sampling
+func (g Geometric) Rand(rng *rand.Rand) int {
+	return g.Quantile(randFloat64(rng))
+}
+
+// Categorical is the distribution over the categories 0 to K-1 with a
+// given probability for each, such as the roll of a loaded die
+type Categorical struct {
+	probabilities []float64
+	cumulative    []float64
+}
+
+// valid reports whether the distribution has any categories, which is
+// only false for a Categorical that didn't come from NewCategorical
+func (c *Categorical) valid() bool {
+	return len(c.cumulative) > 0
+}
+
+// NewCategorical accepts a non-negative weight for each category and
+// returns the categorical distribution with probabilities proportional to
+// the weights
+func NewCategorical(weights []float64) (*Categorical, error) {
+	sum, err := checkWeights(len(weights), weights)
+	if err != nil {
+		return nil, err
+	}
+	c := &Categorical{
+		probabilities: make([]float64, len(weights)),
+		cumulative:    make([]float64, len(weights)),
+	}
+	var total float64
+	for i, weight := range weights {
+		c.probabilities[i] = weight / sum
+		total += c.probabilities[i]
+		c.cumulative[i] = total
+	}
+	// rounding can leave the total just short of 1
+	c.cumulative[len(weights)-1] = 1
+	return c, nil
+}
+
+// Probabilities returns a copy of the probability of each category
+func (c *Categorical) Probabilities() []float64 {
+	return copyVector(c.probabilities)
+}
+
+// PMF returns the probability of category k
+func (c *Categorical) PMF(k int) float64 {
+	if k < 0 || k >= len(c.probabilities) {
+		return 0
+	}
+	return c.probabilities[k]
+}
+
+// LogPMF returns the log of the probability of category k
+func (c *Categorical) LogPMF(k int) float64 {
+	return math.Log(c.PMF(k))
+}
+
+// CDF returns the probability of a category less than or equal to k
+func (c *Categorical) CDF(k int) float64 {
+	if k < 0 {
+		return 0
+	} else if k >= len(c.cumulative) {
+		return 1
+	}
+	return c.cumulative[k]
+}
+
+// Quantile returns the smallest category whose CDF is at least p
+func (c *Categorical) Quantile(p float64) int {
+	if !isProbability(p) || !c.valid() {
+		return InvalidQuantile
+	}
+	return sort.SearchFloat64s(c.cumulative, p)
+}
+
+// Mean returns the mean of the category numbers
+func (c *Categorical) Mean() float64 {
+	if !c.valid() {
+		return math.NaN()
+	}
+	var mean float64
+	for k, probability := range c.probabilities {
+		mean += float64(k) * probability
+	}
+	return mean
+}
+
+// Variance returns the variance of the category numbers
+func (c *Categorical) Variance() float64 {
+	if !c.valid() {
+		return math.NaN()
+	}
+	mean := c.Mean()
+	var variance float64
+	for k, probability := range c.probabilities {
+		variance += (float64(k) - mean) * (float64(k) - mean) * probability
+	}
+	return variance
+}
+
+// Rand returns a random category. Categories with a probability of 0 are
+// never returned
+func (c *Categorical) Rand(rng *rand.Rand) int {
+	if !c.valid() {
+		return InvalidQuantile
+	}
+	u := randFloat64(rng)
+	return sort.Search(len(c.cumulative), func(i int) bool { return c.cumulative[i] > u })
+}
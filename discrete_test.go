@@ -0,0 +1,242 @@
+package mlscratchlib
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// discreteDistributions covers every DiscreteDistribution implementation
+func discreteDistributions(t *testing.T) []DiscreteDistribution {
+	categorical, err := NewCategorical([]float64{1, 0, 3, 2})
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	return []DiscreteDistribution{
+		Bernoulli{P: 0.3},
+		Binomial{N: 20, P: 0.35},
+		Poisson{Lambda: 4.5},
+		Geometric{P: 0.2},
+		categorical,
+	}
+}
+
+func TestDiscreteCDF(t *testing.T) {
+	// the CDF is the running sum of the PMF
+	for _, d := range discreteDistributions(t) {
+		var sum float64
+		for k := 0; k <= 40; k++ {
+			sum += d.PMF(k)
+			result := d.CDF(k)
+
+			if !almostEqual(result, sum, 1e-12) {
+				t.Errorf("%#v k = %v\nExpected result of:\n%v\ngot result:\n%v", d, k, sum, result)
+			}
+
+			if d.PMF(k) > 0 && !almostEqual(d.LogPMF(k), math.Log(d.PMF(k)), 1e-12) {
+				t.Errorf("%#v k = %v\nExpected result of:\n%v\ngot result:\n%v", d, k, math.Log(d.PMF(k)), d.LogPMF(k))
+			}
+		}
+	}
+}
+
+func TestDiscreteQuantile(t *testing.T) {
+	for _, d := range discreteDistributions(t) {
+		for _, p := range []float64{0.01, 0.2, 0.5, 0.7, 0.99} {
+			k := d.Quantile(p)
+
+			// the quantile is the smallest k that reaches p
+			if d.CDF(k) < p || d.CDF(k-1) >= p {
+				t.Errorf("%#v p = %v got %v with CDFs %v and %v", d, p, k, d.CDF(k-1), d.CDF(k))
+			}
+		}
+	}
+
+	// probabilities outside [0, 1] have no quantile
+	for _, d := range discreteDistributions(t) {
+		for _, p := range []float64{-0.5, 1.5, math.NaN()} {
+			if result := d.Quantile(p); result != InvalidQuantile {
+				t.Errorf("%#v p = %v\nExpected result of:\n%v\ngot result:\n%v", d, p, InvalidQuantile, result)
+			}
+		}
+	}
+}
+
+func TestDiscreteInvalidParameters(t *testing.T) {
+	invalid := []DiscreteDistribution{
+		Bernoulli{P: -0.1},
+		Bernoulli{P: 1.5},
+		Binomial{N: -1, P: 0.5},
+		Binomial{N: 10, P: 2},
+		Poisson{Lambda: -1},
+		Poisson{Lambda: math.NaN()},
+		Geometric{P: 0},
+		Geometric{P: 1.5},
+		&Categorical{},
+	}
+
+	for _, d := range invalid {
+		if result := d.Quantile(0.5); result != InvalidQuantile {
+			t.Errorf("%#v\nExpected result of:\n%v\ngot result:\n%v", d, InvalidQuantile, result)
+		}
+
+		if result := d.Rand(nil); result != InvalidQuantile {
+			t.Errorf("%#v\nExpected result of:\n%v\ngot result:\n%v", d, InvalidQuantile, result)
+		}
+
+		if !math.IsNaN(d.Mean()) || !math.IsNaN(d.Variance()) {
+			t.Errorf("%#v\nExpected a mean and variance of NaN, got %v and %v", d, d.Mean(), d.Variance())
+		}
+	}
+
+	// a Categorical without categories still has a PMF of 0 everywhere
+	for _, d := range invalid[:len(invalid)-1] {
+		if !math.IsNaN(d.PMF(1)) || !math.IsNaN(d.LogPMF(1)) || !math.IsNaN(d.CDF(1)) {
+			t.Errorf("%#v\nExpected NaN, got %v, %v and %v", d, d.PMF(1), d.LogPMF(1), d.CDF(1))
+		}
+	}
+
+	// the edges of the valid ranges are still distributions
+	for _, d := range []DiscreteDistribution{Bernoulli{P: 0}, Binomial{N: 0, P: 1}, Poisson{Lambda: 0}, Geometric{P: 1}} {
+		if result := d.Quantile(0.5); result == InvalidQuantile || d.CDF(result) < 0.5 {
+			t.Errorf("%#v\nExpected the median, got %v", d, result)
+		}
+	}
+}
+
+func TestDiscreteRand(t *testing.T) {
+	const samples = 100000
+	rng := rand.New(rand.NewSource(1))
+
+	for _, d := range discreteDistributions(t) {
+		var acc Accumulator
+		for i := 0; i < samples; i++ {
+			acc.Add(float64(d.Rand(rng)))
+		}
+
+		if math.Abs(acc.Mean()-d.Mean()) > 5*math.Sqrt(d.Variance()/samples) {
+			t.Errorf("%#v\nExpected a mean near:\n%v\ngot result:\n%v", d, d.Mean(), acc.Mean())
+		}
+
+		if math.Abs(acc.Variance()/d.Variance()-1) > 0.05 {
+			t.Errorf("%#v\nExpected a variance near:\n%v\ngot result:\n%v", d, d.Variance(), acc.Variance())
+		}
+	}
+}
+
+func TestBinomial(t *testing.T) {
+	var expected, result float64
+	b := Binomial{N: 10, P: 0.5}
+
+	// 10 choose 3 / 2^10
+	result = b.PMF(3)
+	expected = 120.0 / 1024
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	// P(at most 1 head in 10 flips)
+	result = b.CDF(1)
+	expected = 11.0 / 1024
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	// a certain outcome
+	result = Binomial{N: 4, P: 1}.PMF(4)
+	expected = 1
+
+	if result != expected {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	// the normal approximation is close for a large number of trials
+	large := Binomial{N: 400, P: 0.3}
+	result = large.NormalApproximationCDF(110)
+	expected = large.CDF(110)
+
+	if math.Abs(result-expected) > 0.005 {
+		t.Errorf("Expected result near:\n%v\ngot result:\n%v", expected, result)
+	}
+}
+
+func TestPoisson(t *testing.T) {
+	var expected, result float64
+	p := Poisson{Lambda: 2}
+
+	result = p.PMF(3)
+	expected = 8 * math.Exp(-2) / 6
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	result = p.CDF(1)
+	expected = 3 * math.Exp(-2)
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	// a large rate starts the quantile search from the normal approximation
+	k := Poisson{Lambda: 1e6}.Quantile(0.5)
+
+	if k != 1000000 {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", 1000000, k)
+	}
+}
+
+func TestGeometric(t *testing.T) {
+	var expected, result float64
+	g := Geometric{P: 0.25}
+
+	result = g.PMF(3)
+	expected = 0.75 * 0.75 * 0.25
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	if result = g.PMF(0); result != 0 {
+		t.Errorf("Expected a probability of 0 before the first trial, got %v", result)
+	}
+
+	if k := g.Quantile(0); k != 1 {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", 1, k)
+	}
+}
+
+func TestCategorical(t *testing.T) {
+	c, err := NewCategorical([]float64{2, 0, 6})
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	expected := []float64{0.25, 0, 0.75}
+	if result := c.Probabilities(); !vectorsAlmostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	// a category with no weight is never drawn
+	rng := rand.New(rand.NewSource(3))
+	for i := 0; i < 1000; i++ {
+		if k := c.Rand(rng); k == 1 {
+			t.Fatalf("Drew a category with a probability of 0")
+		}
+	}
+
+	_, err = NewCategorical([]float64{1, -1}) // test for negative weights
+
+	if err == nil {
+		t.Errorf("Function accepted a negative weight")
+	}
+
+	_, err = NewCategorical(nil) // test for no categories
+
+	if err == nil {
+		t.Errorf("Function accepted no categories")
+	}
+}
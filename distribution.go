@@ -0,0 +1,512 @@
+package mlscratchlib
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Distribution is a continuous probability distribution with fixed
+// parameters, so that code such as a simulation can work with any of them.
+// Rand draws from rng, or from the global source in math/rand when rng is
+// nil. Mean and Variance return +Inf when the integral diverges and NaN
+// when it is undefined
+type Distribution interface {
+	PDF(x float64) float64
+	LogPDF(x float64) float64
+	CDF(x float64) float64
+	Quantile(p float64) float64
+	Mean() float64
+	Variance() float64
+	Rand(rng *rand.Rand) float64
+}
+
+// randFloat64 returns a uniform number in [0, 1) from rng, or from the
+// global source when rng is nil
+func randFloat64(rng *rand.Rand) float64 {
+	if rng == nil {
+		return rand.Float64()
+	}
+	return rng.Float64()
+}
+
+// randNormFloat64 returns a standard normal number from rng, or from the
+// global source when rng is nil
+func randNormFloat64(rng *rand.Rand) float64 {
+	if rng == nil {
+		return rand.NormFloat64()
+	}
+	return rng.NormFloat64()
+}
+
+// randGamma returns a number from a gamma distribution with the given shape
+// and a scale of 1, using the method of Marsaglia and Tsang (2000). Shapes
+// below 1 are boosted by 1 and scaled back down by U^(1/shape)
+func randGamma(rng *rand.Rand, shape float64) float64 {
+	if shape < 1 {
+		return randGamma(rng, shape+1) * math.Pow(randFloat64(rng), 1/shape)
+	}
+	d := shape - 1.0/3
+	c := 1 / math.Sqrt(9*d)
+	for {
+		x := randNormFloat64(rng)
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+		u := randFloat64(rng)
+		if u < 1-0.0331*x*x*x*x || math.Log(u) < x*x/2+d*(1-v+math.Log(v)) {
+			return d * v
+		}
+	}
+}
+
+// Uniform is the continuous uniform distribution over [Min, Max)
+type Uniform struct {
+	Min float64
+	Max float64
+}
+
+// PDF returns the density of the distribution at x, scaling
+// UniformProbabilityDistribution to the interval
+func (u Uniform) PDF(x float64) float64 {
+	width := u.Max - u.Min
+	return UniformProbabilityDistribution((x-u.Min)/width) / width
+}
+
+// LogPDF returns the log of the density at x
+func (u Uniform) LogPDF(x float64) float64 {
+	return math.Log(u.PDF(x))
+}
+
+// CDF returns the probability that a random number is less than or equal
+// to x, scaling UniformCumulativeDensity to the interval
+func (u Uniform) CDF(x float64) float64 {
+	return UniformCumulativeDensity((x - u.Min) / (u.Max - u.Min))
+}
+
+// Quantile returns the number whose CDF is p
+func (u Uniform) Quantile(p float64) float64 {
+	if !(p >= 0 && p <= 1) {
+		return math.NaN()
+	}
+	return u.Min + p*(u.Max-u.Min)
+}
+
+// Mean returns the midpoint of the interval
+func (u Uniform) Mean() float64 {
+	return (u.Min + u.Max) / 2
+}
+
+// Variance returns the variance of the distribution, (Max - Min)^2 / 12
+func (u Uniform) Variance() float64 {
+	return (u.Max - u.Min) * (u.Max - u.Min) / 12
+}
+
+// Rand returns a random number from the distribution
+func (u Uniform) Rand(rng *rand.Rand) float64 {
+	return u.Min + randFloat64(rng)*(u.Max-u.Min)
+}
+
+// Normal is the normal distribution with mean Mu and standard deviation
+// Sigma
+type Normal struct {
+	Mu    float64
+	Sigma float64
+}
+
+// PDF returns the density of the distribution at x using
+// NormalProbabilityDistribution
+func (n Normal) PDF(x float64) float64 {
+	return NormalProbabilityDistribution(x, n.Mu, n.Sigma)
+}
+
+// LogPDF returns the log of the density at x. It is computed directly
+// rather than as the log of PDF, which underflows to 0 in the tails
+func (n Normal) LogPDF(x float64) float64 {
+	z := (x - n.Mu) / n.Sigma
+	return -z*z/2 - math.Log(n.Sigma*math.Sqrt(2*math.Pi))
+}
+
+// CDF returns the probability that a random number is less than or equal
+// to x using NormalCDF
+func (n Normal) CDF(x float64) float64 {
+	return NormalCDF(x, n.Mu, n.Sigma)
+}
+
+// Quantile returns the number whose CDF is p using InverseNormalCDF, which
+// searches within 10 standard deviations of the mean. A p of 0 or 1
+// returns an infinity
+func (n Normal) Quantile(p float64) float64 {
+	if !(p >= 0 && p <= 1) {
+		return math.NaN()
+	} else if p == 0 {
+		return math.Inf(-1)
+	} else if p == 1 {
+		return math.Inf(1)
+	}
+	return InverseNormalCDF(p, n.Mu, n.Sigma, 1e-12)
+}
+
+// Mean returns Mu
+func (n Normal) Mean() float64 {
+	return n.Mu
+}
+
+// Variance returns Sigma squared
+func (n Normal) Variance() float64 {
+	return n.Sigma * n.Sigma
+}
+
+// Rand returns a random number from the distribution
+func (n Normal) Rand(rng *rand.Rand) float64 {
+	return n.Mu + n.Sigma*randNormFloat64(rng)
+}
+
+// StudentT is Student's t distribution with DF degrees of freedom
+type StudentT struct {
+	DF float64
+}
+
+// PDF returns the density of the distribution at x using StudentTPDF
+func (s StudentT) PDF(x float64) float64 {
+	return StudentTPDF(x, s.DF)
+}
+
+// LogPDF returns the log of the density at x
+func (s StudentT) LogPDF(x float64) float64 {
+	return math.Log(s.PDF(x))
+}
+
+// CDF returns the probability that a random number is less than or equal
+// to x using StudentTCDF
+func (s StudentT) CDF(x float64) float64 {
+	return StudentTCDF(x, s.DF)
+}
+
+// Quantile returns the number whose CDF is p using InverseStudentTCDF
+func (s StudentT) Quantile(p float64) float64 {
+	return InverseStudentTCDF(p, s.DF, 0)
+}
+
+// Mean returns 0 when DF is greater than 1 and NaN otherwise
+func (s StudentT) Mean() float64 {
+	if s.DF > 1 {
+		return 0
+	}
+	return math.NaN()
+}
+
+// Variance returns DF / (DF - 2) when DF is greater than 2, +Inf when it
+// is between 1 and 2, and NaN otherwise
+func (s StudentT) Variance() float64 {
+	if s.DF > 2 {
+		return s.DF / (s.DF - 2)
+	} else if s.DF > 1 {
+		return math.Inf(1)
+	}
+	return math.NaN()
+}
+
+// Rand returns a random number from the distribution, a standard normal
+// number divided by the square root of a chi-square number over DF
+func (s StudentT) Rand(rng *rand.Rand) float64 {
+	if math.IsInf(s.DF, 1) {
+		return randNormFloat64(rng)
+	}
+	return randNormFloat64(rng) / math.Sqrt(2*randGamma(rng, s.DF/2)/s.DF)
+}
+
+// ChiSquare is the chi-square distribution with DF degrees of freedom
+type ChiSquare struct {
+	DF float64
+}
+
+// PDF returns the density of the distribution at x using ChiSquarePDF
+func (c ChiSquare) PDF(x float64) float64 {
+	return ChiSquarePDF(x, c.DF)
+}
+
+// LogPDF returns the log of the density at x
+func (c ChiSquare) LogPDF(x float64) float64 {
+	return math.Log(c.PDF(x))
+}
+
+// CDF returns the probability that a random number is less than or equal
+// to x using ChiSquareCDF
+func (c ChiSquare) CDF(x float64) float64 {
+	return ChiSquareCDF(x, c.DF)
+}
+
+// Quantile returns the number whose CDF is p using InverseChiSquareCDF
+func (c ChiSquare) Quantile(p float64) float64 {
+	return InverseChiSquareCDF(p, c.DF, 0)
+}
+
+// Mean returns DF
+func (c ChiSquare) Mean() float64 {
+	return c.DF
+}
+
+// Variance returns 2 * DF
+func (c ChiSquare) Variance() float64 {
+	return 2 * c.DF
+}
+
+// Rand returns a random number from the distribution
+func (c ChiSquare) Rand(rng *rand.Rand) float64 {
+	return 2 * randGamma(rng, c.DF/2)
+}
+
+// F is the F distribution with DF1 numerator and DF2 denominator degrees
+// of freedom
+type F struct {
+	DF1 float64
+	DF2 float64
+}
+
+// PDF returns the density of the distribution at x using FPDF
+func (f F) PDF(x float64) float64 {
+	return FPDF(x, f.DF1, f.DF2)
+}
+
+// LogPDF returns the log of the density at x
+func (f F) LogPDF(x float64) float64 {
+	return math.Log(f.PDF(x))
+}
+
+// CDF returns the probability that a random number is less than or equal
+// to x using FCDF
+func (f F) CDF(x float64) float64 {
+	return FCDF(x, f.DF1, f.DF2)
+}
+
+// Quantile returns the number whose CDF is p using InverseFCDF
+func (f F) Quantile(p float64) float64 {
+	return InverseFCDF(p, f.DF1, f.DF2, 0)
+}
+
+// Mean returns DF2 / (DF2 - 2) when DF2 is greater than 2 and +Inf
+// otherwise
+func (f F) Mean() float64 {
+	if f.DF2 > 2 {
+		return f.DF2 / (f.DF2 - 2)
+	}
+	return math.Inf(1)
+}
+
+// Variance returns the variance of the distribution when DF2 is greater
+// than 4, +Inf when it is between 2 and 4, and NaN otherwise
+func (f F) Variance() float64 {
+	if f.DF2 > 4 {
+		return 2 * f.DF2 * f.DF2 * (f.DF1 + f.DF2 - 2) /
+			(f.DF1 * (f.DF2 - 2) * (f.DF2 - 2) * (f.DF2 - 4))
+	} else if f.DF2 > 2 {
+		return math.Inf(1)
+	}
+	return math.NaN()
+}
+
+// Rand returns a random number from the distribution, the ratio of two
+// chi-square numbers each divided by their degrees of freedom
+func (f F) Rand(rng *rand.Rand) float64 {
+	return (randGamma(rng, f.DF1/2) / f.DF1) / (randGamma(rng, f.DF2/2) / f.DF2)
+}
+
+// Gamma is the gamma distribution with the given Shape and Scale
+type Gamma struct {
+	Shape float64
+	Scale float64
+}
+
+// PDF returns the density of the distribution at x using GammaPDF
+func (g Gamma) PDF(x float64) float64 {
+	return GammaPDF(x, g.Shape, g.Scale)
+}
+
+// LogPDF returns the log of the density at x
+func (g Gamma) LogPDF(x float64) float64 {
+	return math.Log(g.PDF(x))
+}
+
+// CDF returns the probability that a random number is less than or equal
+// to x using GammaCDF
+func (g Gamma) CDF(x float64) float64 {
+	return GammaCDF(x, g.Shape, g.Scale)
+}
+
+// Quantile returns the number whose CDF is p using InverseGammaCDF
+func (g Gamma) Quantile(p float64) float64 {
+	return InverseGammaCDF(p, g.Shape, g.Scale, 0)
+}
+
+// Mean returns Shape * Scale
+func (g Gamma) Mean() float64 {
+	return g.Shape * g.Scale
+}
+
+// Variance returns Shape * Scale^2
+func (g Gamma) Variance() float64 {
+	return g.Shape * g.Scale * g.Scale
+}
+
+// Rand returns a random number from the distribution
+func (g Gamma) Rand(rng *rand.Rand) float64 {
+	return g.Scale * randGamma(rng, g.Shape)
+}
+
+// Beta is the beta distribution over [0, 1] with shape parameters Alpha
+// and Beta
+type Beta struct {
+	Alpha float64
+	Beta  float64
+}
+
+// PDF returns the density of the distribution at x using BetaPDF
+func (b Beta) PDF(x float64) float64 {
+	return BetaPDF(x, b.Alpha, b.Beta)
+}
+
+// LogPDF returns the log of the density at x
+func (b Beta) LogPDF(x float64) float64 {
+	return math.Log(b.PDF(x))
+}
+
+// CDF returns the probability that a random number is less than or equal
+// to x using BetaCDF
+func (b Beta) CDF(x float64) float64 {
+	return BetaCDF(x, b.Alpha, b.Beta)
+}
+
+// Quantile returns the number whose CDF is p using InverseBetaCDF
+func (b Beta) Quantile(p float64) float64 {
+	return InverseBetaCDF(p, b.Alpha, b.Beta, 0)
+}
+
+// Mean returns Alpha / (Alpha + Beta)
+func (b Beta) Mean() float64 {
+	return b.Alpha / (b.Alpha + b.Beta)
+}
+
+// Variance returns the variance of the distribution,
+// Alpha * Beta / ((Alpha + Beta)^2 * (Alpha + Beta + 1))
+func (b Beta) Variance() float64 {
+	sum := b.Alpha + b.Beta
+	return b.Alpha * b.Beta / (sum * sum * (sum + 1))
+}
+
+// Rand returns a random number from the distribution, X / (X + Y) for
+// gamma numbers X and Y with shapes Alpha and Beta
+func (b Beta) Rand(rng *rand.Rand) float64 {
+	x := randGamma(rng, b.Alpha)
+	return x / (x + randGamma(rng, b.Beta))
+}
+
+// Exponential is the exponential distribution of the waiting time between
+// events that happen at an average rate of Rate per unit of time
+type Exponential struct {
+	Rate float64
+}
+
+// PDF returns the density of the distribution at x
+func (e Exponential) PDF(x float64) float64 {
+	if x < 0 {
+		return 0
+	}
+	return e.Rate * math.Exp(-e.Rate*x)
+}
+
+// LogPDF returns the log of the density at x
+func (e Exponential) LogPDF(x float64) float64 {
+	if x < 0 {
+		return math.Inf(-1)
+	}
+	return math.Log(e.Rate) - e.Rate*x
+}
+
+// CDF returns the probability that a random number is less than or equal
+// to x, 1 - e^(-Rate * x)
+func (e Exponential) CDF(x float64) float64 {
+	if x < 0 {
+		return 0
+	}
+	return -math.Expm1(-e.Rate * x)
+}
+
+// Quantile returns the number whose CDF is p, which has the closed form
+// -log(1 - p) / Rate
+func (e Exponential) Quantile(p float64) float64 {
+	if !(p >= 0 && p <= 1) {
+		return math.NaN()
+	}
+	return -math.Log1p(-p) / e.Rate
+}
+
+// Mean returns 1 / Rate
+func (e Exponential) Mean() float64 {
+	return 1 / e.Rate
+}
+
+// Variance returns 1 / Rate^2
+func (e Exponential) Variance() float64 {
+	return 1 / (e.Rate * e.Rate)
+}
+
+// Rand returns a random number from the distribution
+func (e Exponential) Rand(rng *rand.Rand) float64 {
+	return -math.Log(randOpenFloat64(rng)) / e.Rate
+}
+
+// LogNormal is the distribution of e^X for a normal number X with mean Mu
+// and standard deviation Sigma, a common model for positive, right skewed
+// quantities such as latencies and incomes
+type LogNormal struct {
+	Mu    float64
+	Sigma float64
+}
+
+// PDF returns the density of the distribution at x
+func (l LogNormal) PDF(x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	return NormalProbabilityDistribution(math.Log(x), l.Mu, l.Sigma) / x
+}
+
+// LogPDF returns the log of the density at x
+func (l LogNormal) LogPDF(x float64) float64 {
+	if x <= 0 {
+		return math.Inf(-1)
+	}
+	return Normal{Mu: l.Mu, Sigma: l.Sigma}.LogPDF(math.Log(x)) - math.Log(x)
+}
+
+// CDF returns the probability that a random number is less than or equal
+// to x using NormalCDF of log(x)
+func (l LogNormal) CDF(x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	return NormalCDF(math.Log(x), l.Mu, l.Sigma)
+}
+
+// Quantile returns the number whose CDF is p, the exponential of the
+// normal quantile
+func (l LogNormal) Quantile(p float64) float64 {
+	return math.Exp(Normal{Mu: l.Mu, Sigma: l.Sigma}.Quantile(p))
+}
+
+// Mean returns e^(Mu + Sigma^2 / 2)
+func (l LogNormal) Mean() float64 {
+	return math.Exp(l.Mu + l.Sigma*l.Sigma/2)
+}
+
+// Variance returns (e^(Sigma^2) - 1) * e^(2 * Mu + Sigma^2)
+func (l LogNormal) Variance() float64 {
+	return math.Expm1(l.Sigma*l.Sigma) * math.Exp(2*l.Mu+l.Sigma*l.Sigma)
+}
+
+// Rand returns a random number from the distribution
+func (l LogNormal) Rand(rng *rand.Rand) float64 {
+	return math.Exp(l.Mu + l.Sigma*randNormFloat64(rng))
+}
@@ -0,0 +1,151 @@
+package mlscratchlib
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// distributions covers every Distribution implementation with parameters
+// that have a finite mean and variance
+var distributions = []Distribution{
+	Uniform{Min: -2, Max: 3},
+	Normal{Mu: 1.5, Sigma: 2},
+	StudentT{DF: 7},
+	ChiSquare{DF: 4},
+	F{DF1: 5, DF2: 12},
+	Gamma{Shape: 0.7, Scale: 3},
+	Beta{Alpha: 2, Beta: 5},
+	Exponential{Rate: 0.5},
+	LogNormal{Mu: 0.2, Sigma: 0.4},
+}
+
+func TestDistributionQuantile(t *testing.T) {
+	for _, d := range distributions {
+		for _, p := range []float64{0.05, 0.25, 0.5, 0.9} {
+			result := d.CDF(d.Quantile(p))
+
+			if !almostEqual(result, p, 1e-9) {
+				t.Errorf("%#v p = %v\nExpected result of:\n%v\ngot result:\n%v", d, p, p, result)
+			}
+		}
+	}
+}
+
+func TestDistributionLogPDF(t *testing.T) {
+	for _, d := range distributions {
+		x := d.Quantile(0.3)
+		result := d.LogPDF(x)
+		expected := math.Log(d.PDF(x))
+
+		if !almostEqual(result, expected, 1e-12) {
+			t.Errorf("%#v\nExpected result of:\n%v\ngot result:\n%v", d, expected, result)
+		}
+	}
+
+	// the normal log density is still finite where the density underflows
+	result := Normal{Mu: 0, Sigma: 1}.LogPDF(40)
+	expected := -800 - math.Log(math.Sqrt(2*math.Pi))
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+}
+
+func TestDistributionRand(t *testing.T) {
+	const samples = 200000
+	rng := rand.New(rand.NewSource(1))
+
+	for _, d := range distributions {
+		var acc Accumulator
+		for i := 0; i < samples; i++ {
+			acc.Add(d.Rand(rng))
+		}
+
+		// allow 5 standard errors of the sample mean
+		if math.Abs(acc.Mean()-d.Mean()) > 5*math.Sqrt(d.Variance()/samples) {
+			t.Errorf("%#v\nExpected a mean near:\n%v\ngot result:\n%v", d, d.Mean(), acc.Mean())
+		}
+
+		if math.Abs(acc.Variance()/d.Variance()-1) > 0.05 {
+			t.Errorf("%#v\nExpected a variance near:\n%v\ngot result:\n%v", d, d.Variance(), acc.Variance())
+		}
+	}
+
+	// the same seed gives the same numbers
+	a := Gamma{Shape: 2, Scale: 1}.Rand(rand.New(rand.NewSource(7)))
+	b := Gamma{Shape: 2, Scale: 1}.Rand(rand.New(rand.NewSource(7)))
+
+	if a != b {
+		t.Errorf("Expected equal draws from equal seeds, got %v and %v", a, b)
+	}
+}
+
+func TestUniformDistribution(t *testing.T) {
+	u := Uniform{Min: 2, Max: 6}
+	var expected, result float64
+
+	result = u.PDF(3)
+	expected = 0.25
+
+	if result != expected {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	result = u.PDF(7)
+	expected = 0
+
+	if result != expected {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	result = u.CDF(5)
+	expected = 0.75
+
+	if result != expected {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	if result = u.Quantile(1.5); !math.IsNaN(result) {
+		t.Errorf("Expected NaN for a probability above 1, got %v", result)
+	}
+}
+
+func TestNormalDistribution(t *testing.T) {
+	n := Normal{Mu: 3, Sigma: 2}
+	var expected, result float64
+
+	// the density scales with the standard deviation
+	result = n.PDF(5)
+	expected = NormalProbabilityDistribution(1, 0, 1) / 2
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	result = n.Quantile(0.975)
+	expected = 3 + 2*1.959963984540054
+
+	if !almostEqual(result, expected, 1e-9) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	if result = n.Quantile(0); !math.IsInf(result, -1) {
+		t.Errorf("Expected -Inf for a probability of 0, got %v", result)
+	}
+}
+
+func TestDistributionMoments(t *testing.T) {
+	// heavy tails without a finite mean or variance
+	if result := (StudentT{DF: 1}).Mean(); !math.IsNaN(result) {
+		t.Errorf("Expected NaN for the mean of a Cauchy distribution, got %v", result)
+	}
+
+	if result := (StudentT{DF: 2}).Variance(); !math.IsInf(result, 1) {
+		t.Errorf("Expected +Inf for the variance of a t distribution with 2 degrees of freedom, got %v", result)
+	}
+
+	if result := (F{DF1: 3, DF2: 2}).Mean(); !math.IsInf(result, 1) {
+		t.Errorf("Expected +Inf for the mean of an F distribution with 2 denominator degrees of freedom, got %v", result)
+	}
+}
@@ -0,0 +1,120 @@
+package mlscratchlib
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// EigenSymmetric accepts a symmetric matrix and returns its eigenvalues
+// sorted from largest to smallest, along with a matrix whose columns are
+// the matching unit length eigenvectors. It uses the cyclic Jacobi method,
+// which repeatedly rotates away the off diagonal elements until the
+// matrix is diagonal
+func EigenSymmetric(a *Matrix) (values []float64, vectors *Matrix, err error) {
+	if a.rows != a.cols {
+		return nil, nil, errors.New("the matrix must be square")
+	}
+	n := a.rows
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if !isClose(a.data[i*n+j], a.data[j*n+i]) {
+				return nil, nil, errors.New("the matrix must be symmetric")
+			}
+		}
+	}
+
+	d := a.Copy()
+	v := Identity(n)
+
+	const maxSweeps = 100
+	converged := false
+	for sweep := 0; sweep < maxSweeps; sweep++ {
+		var offDiagonal, diagonal float64
+		for i := 0; i < n; i++ {
+			diagonal += d.data[i*n+i] * d.data[i*n+i]
+			for j := i + 1; j < n; j++ {
+				offDiagonal += d.data[i*n+j] * d.data[i*n+j]
+			}
+		}
+		if offDiagonal <= machineEpsilon*machineEpsilon*diagonal {
+			converged = true
+			break
+		}
+
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				jacobiRotate(d, v, p, q)
+			}
+		}
+	}
+	if !converged {
+		return nil, nil, errors.New("the eigenvalues did not converge")
+	}
+
+	// sort the eigenpairs from the largest eigenvalue to the smallest
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i int, j int) bool {
+		return d.data[order[i]*n+order[i]] > d.data[order[j]*n+order[j]]
+	})
+
+	values = make([]float64, n)
+	vectors = NewMatrix(n, n)
+	for k, index := range order {
+		values[k] = d.data[index*n+index]
+		eigenvector := v.Column(index)
+		magnitude, _ := Magnitude(eigenvector)
+		for i, element := range eigenvector {
+			vectors.data[i*n+k] = element / magnitude
+		}
+	}
+	return values, vectors, nil
+}
+
+// jacobiRotate applies the plane rotation that zeroes d[p][q] to both
+// sides of d, and accumulates the rotation into the columns of v
+func jacobiRotate(d *Matrix, v *Matrix, p int, q int) {
+	n := d.rows
+	apq := d.data[p*n+q]
+	if apq == 0 {
+		return
+	}
+	app, aqq := d.data[p*n+p], d.data[q*n+q]
+
+	// choose the smaller rotation angle for numerical stability
+	theta := (aqq - app) / (2 * apq)
+	t := 1 / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+	if theta < 0 {
+		t = -t
+	}
+	c := 1 / math.Sqrt(t*t+1)
+	s := t * c
+
+	for k := 0; k < n; k++ {
+		dkp, dkq := d.data[k*n+p], d.data[k*n+q]
+		d.data[k*n+p] = c*dkp - s*dkq
+		d.data[k*n+q] = s*dkp + c*dkq
+	}
+	for k := 0; k < n; k++ {
+		dpk, dqk := d.data[p*n+k], d.data[q*n+k]
+		d.data[p*n+k] = c*dpk - s*dqk
+		d.data[q*n+k] = s*dpk + c*dqk
+	}
+	d.data[p*n+q], d.data[q*n+p] = 0, 0
+
+	for k := 0; k < n; k++ {
+		vkp, vkq := v.data[k*n+p], v.data[k*n+q]
+		v.data[k*n+p] = c*vkp - s*vkq
+		v.data[k*n+q] = s*vkp + c*vkq
+	}
+}
+
+// isClose reports whether a and b are equal up to rounding error
+// relative to their size
+func isClose(a float64, b float64) bool {
+	scale := math.Max(1, math.Max(math.Abs(a), math.Abs(b)))
+	return math.Abs(a-b) <= 1e-10*scale
+}
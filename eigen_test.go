@@ -0,0 +1,62 @@
+package mlscratchlib
+
+import "testing"
+
+// sample symmetric matrix for testing the eigen decomposition
+var symmetric3a = [][]float64{{4, 1, 2}, {1, 3, 0}, {2, 0, 5}}
+
+func TestEigenSymmetric(t *testing.T) {
+	a := mustMatrix(t, symmetric3a)
+
+	values, vectors, err := EigenSymmetric(a)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	for i := 1; i < len(values); i++ {
+		if values[i] > values[i-1] {
+			t.Errorf("Eigenvalues are not sorted descending: %v", values)
+		}
+	}
+
+	// the trace is the sum of the eigenvalues
+	if !almostEqual(SumValues(values), 12, 1e-10) {
+		t.Errorf("Expected eigenvalues to sum to 12, got %v", values)
+	}
+
+	// check A*v = lambda*v and |v| = 1 for every eigenpair
+	for k, value := range values {
+		vector := vectors.Column(k)
+		result, _ := a.MulVector(vector)
+		expected := ScalarMultiply(value, vector)
+
+		if !vectorsAlmostEqual(result, expected, 1e-10) {
+			t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+		}
+
+		magnitude, _ := Magnitude(vector)
+		if !almostEqual(magnitude, 1, 1e-12) {
+			t.Errorf("Expected unit eigenvector, got magnitude %v", magnitude)
+		}
+	}
+
+	values, vectors, err = EigenSymmetric(mustMatrix(t, [][]float64{{2, 0}, {0, 3}}))
+	expected := []float64{3, 2}
+
+	if !vectorsAlmostEqual(values, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, values)
+	}
+
+	values, vectors, err = EigenSymmetric(mustMatrix(t, square3a)) // test the non-symmetric logic
+
+	if err == nil || values != nil {
+		t.Errorf("Function accepted a non-symmetric matrix, got %v", values)
+	}
+
+	values, vectors, err = EigenSymmetric(mustMatrix(t, matrix2x3)) // test the non-square logic
+
+	if err == nil || values != nil {
+		t.Errorf("Function accepted a non-square matrix, got %v", values)
+	}
+}
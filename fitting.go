@@ -0,0 +1,262 @@
+package mlscratchlib
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// FitResult describes how well a distribution fitted by maximum likelihood
+// explains the data. AIC and BIC penalize the log-likelihood by the number
+// of fitted parameters, and when comparing models fitted to the same data
+// the one with the lowest value is preferred
+type FitResult struct {
+	LogLikelihood float64
+	AIC           float64
+	BIC           float64
+}
+
+// newFitResult returns the FitResult for a log-likelihood from a model
+// with the given number of parameters fitted to n values
+func newFitResult(logLikelihood float64, parameters int, n int) FitResult {
+	k := float64(parameters)
+	return FitResult{
+		LogLikelihood: logLikelihood,
+		AIC:           2*k - 2*logLikelihood,
+		BIC:           k*math.Log(float64(n)) - 2*logLikelihood,
+	}
+}
+
+// logLikelihood returns the sum of d.LogPDF over the vector
+func logLikelihood(d Distribution, vector []float64) float64 {
+	var sum float64
+	for _, x := range vector {
+		sum += d.LogPDF(x)
+	}
+	return sum
+}
+
+// checkPositive returns an error if the vector is empty or has an element
+// that is not greater than 0
+func checkPositive(vector []float64) error {
+	if len(vector) < 1 {
+		return errors.New("something went wrong vector length is 0")
+	}
+	for _, x := range vector {
+		if !(x > 0) {
+			return errors.New("every value must be greater than 0")
+		}
+	}
+	return nil
+}
+
+// populationStandardDeviation returns the square root of the mean squared
+// deviation from the mean, which is the maximum likelihood estimate of a
+// normal standard deviation
+func populationStandardDeviation(vector []float64) float64 {
+	n := float64(len(vector))
+	return math.Sqrt(VarianceVector(vector) * (n - 1) / n)
+}
+
+// FitNormal accepts a sample and returns the maximum likelihood normal
+// distribution, whose standard deviation divides by n rather than n - 1
+func FitNormal(vector []float64) (Normal, FitResult, error) {
+	if len(vector) < 2 {
+		return Normal{}, FitResult{}, errors.New("fitting a normal distribution needs at least 2 values")
+	}
+	n := Normal{Mu: VectorMean(vector), Sigma: populationStandardDeviation(vector)}
+	if n.Sigma == 0 {
+		return Normal{}, FitResult{}, errors.New("the standard deviation of the sample is 0")
+	}
+	return n, newFitResult(logLikelihood(n, vector), 2, len(vector)), nil
+}
+
+// FitExponential accepts a sample of non-negative values and returns the
+// maximum likelihood exponential distribution, whose rate is 1 / mean
+func FitExponential(vector []float64) (Exponential, FitResult, error) {
+	if len(vector) < 1 {
+		return Exponential{}, FitResult{}, errors.New("something went wrong vector length is 0")
+	}
+	for _, x := range vector {
+		if x < 0 || math.IsNaN(x) {
+			return Exponential{}, FitResult{}, errors.New("every value must not be negative")
+		}
+	}
+	mean := VectorMean(vector)
+	if mean == 0 {
+		return Exponential{}, FitResult{}, errors.New("the mean of the sample is 0")
+	}
+	e := Exponential{Rate: 1 / mean}
+	return e, newFitResult(logLikelihood(e, vector), 1, len(vector)), nil
+}
+
+// FitGamma accepts a sample of positive values and returns the maximum
+// likelihood gamma distribution. The shape has no closed form, so it is
+// found with Newton's method on log(shape) - digamma(shape) = s, where
+// s = log(mean) - mean(log(x)), starting from the approximation in Minka
+// (2002). The scale is then mean / shape
+func FitGamma(vector []float64) (Gamma, FitResult, error) {
+	if err := checkPositive(vector); err != nil {
+		return Gamma{}, FitResult{}, err
+	}
+	mean := VectorMean(vector)
+	var meanLog float64
+	for _, x := range vector {
+		meanLog += math.Log(x)
+	}
+	meanLog /= float64(len(vector))
+	s := math.Log(mean) - meanLog
+	if !(s > 0) {
+		return Gamma{}, FitResult{}, errors.New("every value in the sample is the same")
+	}
+
+	shape := (3 - s + math.Sqrt((s-3)*(s-3)+24*s)) / (12 * s)
+	for i := 0; i < 100; i++ {
+		step := (math.Log(shape) - digamma(shape) - s) / (1/shape - trigamma(shape))
+		// Newton's method can overshoot below 0 from a poor start
+		next := math.Max(shape-step, shape/2)
+		if math.Abs(next-shape) <= 1e-14*shape {
+			shape = next
+			break
+		}
+		shape = next
+	}
+	g := Gamma{Shape: shape, Scale: mean / shape}
+	return g, newFitResult(logLikelihood(g, vector), 2, len(vector)), nil
+}
+
+// FitPoisson accepts a sample of counts, which must be non-negative
+// integers stored as float64, and returns the maximum likelihood Poisson
+// distribution, whose rate is the mean
+func FitPoisson(vector []float64) (Poisson, FitResult, error) {
+	if len(vector) < 1 {
+		return Poisson{}, FitResult{}, errors.New("something went wrong vector length is 0")
+	}
+	for _, x := range vector {
+		if x < 0 || x != math.Trunc(x) || x > math.MaxInt32 {
+			return Poisson{}, FitResult{}, errors.New("every value must be a non-negative integer")
+		}
+	}
+	p := Poisson{Lambda: VectorMean(vector)}
+	var sum float64
+	for _, x := range vector {
+		sum += p.LogPMF(int(x))
+	}
+	return p, newFitResult(sum, 1, len(vector)), nil
+}
+
+// FitLogNormal accepts a sample of positive values and returns the
+// maximum likelihood log-normal distribution, which is the maximum
+// likelihood normal distribution of the logs of the values
+func FitLogNormal(vector []float64) (LogNormal, FitResult, error) {
+	if err := checkPositive(vector); err != nil {
+		return LogNormal{}, FitResult{}, err
+	} else if len(vector) < 2 {
+		return LogNormal{}, FitResult{}, errors.New("fitting a log-normal distribution needs at least 2 values")
+	}
+	logs := make([]float64, len(vector))
+	for i, x := range vector {
+		logs[i] = math.Log(x)
+	}
+	l := LogNormal{Mu: VectorMean(logs), Sigma: populationStandardDeviation(logs)}
+	if l.Sigma == 0 {
+		return LogNormal{}, FitResult{}, errors.New("every value in the sample is the same")
+	}
+	return l, newFitResult(logLikelihood(l, vector), 2, len(vector)), nil
+}
+
+// GoodnessOfFit holds the statistic of a goodness of fit test and the
+// p-value for the null hypothesis that the sample came from the
+// distribution it was tested against
+type GoodnessOfFit struct {
+	Statistic float64
+	PValue    float64
+}
+
+// sortedCopy returns a sorted copy of the vector, or an error if it is
+// empty
+func sortedCopy(vector []float64) ([]float64, error) {
+	if len(vector) < 1 {
+		return nil, errors.New("something went wrong vector length is 0")
+	}
+	sorted := copyVector(vector)
+	sort.Float64s(sorted)
+	return sorted, nil
+}
+
+// KolmogorovSmirnovTest accepts a sample and a CDF, such as
+// func(x float64) float64 { return NormalCDF(x, mean, sigma) } or the CDF
+// method of a fitted Distribution, and returns the largest distance between
+// the empirical CDF of the sample and the CDF. The p-value uses the
+// asymptotic Kolmogorov distribution with the small sample correction of
+// Stephens (1970). It assumes the CDF was chosen without looking at the
+// sample, so when the parameters were fitted to the same sample it is too
+// large and the test rarely rejects
+func KolmogorovSmirnovTest(vector []float64, cdf func(float64) float64) (GoodnessOfFit, error) {
+	sorted, err := sortedCopy(vector)
+	if err != nil {
+		return GoodnessOfFit{}, err
+	}
+	n := float64(len(sorted))
+	var d float64
+	for i, x := range sorted {
+		f := cdf(x)
+		d = math.Max(d, math.Max(f-float64(i)/n, float64(i+1)/n-f))
+	}
+	sqrtN := math.Sqrt(n)
+	return GoodnessOfFit{Statistic: d, PValue: kolmogorovPValue((sqrtN + 0.12 + 0.11/sqrtN) * d)}, nil
+}
+
+// kolmogorovPValue returns the probability that the Kolmogorov distribution
+// exceeds lambda, 2 * sum over j of (-1)^(j-1) * e^(-2 * j^2 * lambda^2)
+func kolmogorovPValue(lambda float64) float64 {
+	if lambda < 0.2 {
+		// the series converges slowly here and the sum is 1 to float64 precision
+		return 1
+	}
+	var sum float64
+	sign := 1.0
+	for j := 1.0; j <= 100; j++ {
+		term := sign * math.Exp(-2*j*j*lambda*lambda)
+		sum += term
+		if math.Abs(term) <= 1e-16*math.Abs(sum) {
+			break
+		}
+		sign = -sign
+	}
+	return math.Max(0, math.Min(1, 2*sum))
+}
+
+// AndersonDarlingTest accepts a sample and a CDF and returns the
+// Anderson-Darling statistic A^2, which weights distances between the
+// empirical CDF and the CDF more heavily in the tails than
+// KolmogorovSmirnovTest does. The p-value uses the approximation to the
+// asymptotic distribution of A^2 by Marsaglia and Marsaglia (2004), with
+// the same caveat about fitted parameters
+func AndersonDarlingTest(vector []float64, cdf func(float64) float64) (GoodnessOfFit, error) {
+	sorted, err := sortedCopy(vector)
+	if err != nil {
+		return GoodnessOfFit{}, err
+	}
+	n := len(sorted)
+	var sum float64
+	for i := 0; i < n; i++ {
+		sum += float64(2*i+1) * (math.Log(cdf(sorted[i])) + math.Log1p(-cdf(sorted[n-1-i])))
+	}
+	statistic := -float64(n) - sum/float64(n)
+	return GoodnessOfFit{Statistic: statistic, PValue: 1 - andersonDarlingCDF(statistic)}, nil
+}
+
+// andersonDarlingCDF returns the probability that the asymptotic
+// distribution of A^2 is less than z
+func andersonDarlingCDF(z float64) float64 {
+	if math.IsNaN(z) {
+		return math.NaN()
+	} else if z <= 0 {
+		return 0
+	} else if z < 2 {
+		return math.Exp(-1.2337141/z) / math.Sqrt(z) *
+			(2.00012 + (0.247105-(0.0649821-(0.0347962-(0.011672-0.00168691*z)*z)*z)*z)*z)
+	}
+	return math.Exp(-math.Exp(1.0776 - (2.30695-(0.43424-(0.082433-(0.008056-0.0003146*z)*z)*z)*z)*z))
+}
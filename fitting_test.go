@@ -0,0 +1,218 @@
+package mlscratchlib
+
+import (
+	"math"
+	"testing"
+)
+
+// drawSample draws n values from d with a fixed seed
+func drawSample(d Distribution, n int, seed int64) []float64 {
+	rng := NewRand(seed)
+	vector := make([]float64, n)
+	for i := range vector {
+		vector[i] = d.Rand(rng)
+	}
+	return vector
+}
+
+func TestFitNormal(t *testing.T) {
+	vector := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+	result, fit, err := FitNormal(vector)
+	expected := Normal{Mu: 5, Sigma: 2}
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !almostEqual(result.Mu, expected.Mu, 1e-12) || !almostEqual(result.Sigma, expected.Sigma, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	expectedLL := -8*math.Log(2*math.Sqrt(2*math.Pi)) - 4
+	if !almostEqual(fit.LogLikelihood, expectedLL, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expectedLL, fit.LogLikelihood)
+	}
+
+	if !almostEqual(fit.AIC, 4-2*expectedLL, 1e-12) || !almostEqual(fit.BIC, 2*math.Log(8)-2*expectedLL, 1e-12) {
+		t.Errorf("Expected AIC %v and BIC %v, got %v", 4-2*expectedLL, 2*math.Log(8)-2*expectedLL, fit)
+	}
+
+	_, _, err = FitNormal([]float64{3, 3, 3}) // test for zero variance
+
+	if err == nil {
+		t.Errorf("Function accepted a sample with a standard deviation of 0")
+	}
+}
+
+func TestFitExponentialPoisson(t *testing.T) {
+	e, fit, err := FitExponential([]float64{1, 2, 3, 6, 8})
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !almostEqual(e.Rate, 0.25, 1e-12) || !almostEqual(fit.LogLikelihood, 5*math.Log(0.25)-5, 1e-12) {
+		t.Errorf("Expected a rate of 0.25, got %v with %v", e.Rate, fit)
+	}
+
+	_, _, err = FitExponential([]float64{1, -2}) // test for negative values
+
+	if err == nil {
+		t.Errorf("Function accepted a negative value")
+	}
+
+	p, fit, err := FitPoisson([]float64{0, 1, 1, 2, 6})
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	expectedLL := Poisson{Lambda: 2}.LogPMF(0) + 2*Poisson{Lambda: 2}.LogPMF(1) +
+		Poisson{Lambda: 2}.LogPMF(2) + Poisson{Lambda: 2}.LogPMF(6)
+	if p.Lambda != 2 || !almostEqual(fit.LogLikelihood, expectedLL, 1e-12) {
+		t.Errorf("Expected a rate of 2 with a log-likelihood of %v, got %v with %v", expectedLL, p.Lambda, fit)
+	}
+
+	_, _, err = FitPoisson([]float64{1, 2.5}) // test for non-integer counts
+
+	if err == nil {
+		t.Errorf("Function accepted a count that is not an integer")
+	}
+}
+
+func TestFitGamma(t *testing.T) {
+	vector := drawSample(Gamma{Shape: 2.5, Scale: 1.5}, 20000, 1)
+	g, _, err := FitGamma(vector)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if math.Abs(g.Shape-2.5) > 0.1 || math.Abs(g.Scale-1.5) > 0.1 {
+		t.Errorf("Expected result near:\n%v\ngot result:\n%v", Gamma{Shape: 2.5, Scale: 1.5}, g)
+	}
+
+	// the fitted shape solves the likelihood equation
+	var meanLog float64
+	for _, x := range vector {
+		meanLog += math.Log(x) / float64(len(vector))
+	}
+	s := math.Log(VectorMean(vector)) - meanLog
+
+	if !almostEqual(math.Log(g.Shape)-digamma(g.Shape), s, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", s, math.Log(g.Shape)-digamma(g.Shape))
+	}
+
+	// a small shape, where the starting approximation is poor
+	g, _, err = FitGamma(drawSample(Gamma{Shape: 0.2, Scale: 1}, 20000, 2))
+
+	if err != nil || math.Abs(g.Shape-0.2) > 0.01 {
+		t.Errorf("Expected a shape near 0.2, got %v (%v)", g.Shape, err)
+	}
+
+	_, _, err = FitGamma([]float64{1, 0, 2}) // test for a value of 0
+
+	if err == nil {
+		t.Errorf("Function accepted a value of 0")
+	}
+}
+
+func TestFitLogNormal(t *testing.T) {
+	vector := drawSample(LogNormal{Mu: 1, Sigma: 0.8}, 5000, 3)
+	l, lognormalFit, err := FitLogNormal(vector)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if math.Abs(l.Mu-1) > 0.05 || math.Abs(l.Sigma-0.8) > 0.05 {
+		t.Errorf("Expected result near:\n%v\ngot result:\n%v", LogNormal{Mu: 1, Sigma: 0.8}, l)
+	}
+
+	// the information criteria pick the right model for skewed data
+	_, normalFit, _ := FitNormal(vector)
+
+	if lognormalFit.AIC >= normalFit.AIC || lognormalFit.BIC >= normalFit.BIC {
+		t.Errorf("Expected the log-normal fit %v to beat the normal fit %v", lognormalFit, normalFit)
+	}
+}
+
+func TestKolmogorovSmirnovTest(t *testing.T) {
+	uniform := Uniform{Min: 0, Max: 1}.CDF
+
+	result, err := KolmogorovSmirnovTest([]float64{0.5}, uniform)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if result.Statistic != 0.5 {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", 0.5, result.Statistic)
+	}
+
+	// the asymptotic 5% and 1% critical values
+	if p := kolmogorovPValue(1.3581); !almostEqual(p, 0.05, 1e-4) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", 0.05, p)
+	}
+
+	if p := kolmogorovPValue(1.6276); !almostEqual(p, 0.01, 1e-4) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", 0.01, p)
+	}
+
+	normal := Normal{Mu: 0, Sigma: 1}
+	result, _ = KolmogorovSmirnovTest(drawSample(normal, 1000, 4), normal.CDF)
+
+	if result.PValue < 0.01 {
+		t.Errorf("Rejected a sample from the tested distribution, %v", result)
+	}
+
+	result, _ = KolmogorovSmirnovTest(drawSample(Exponential{Rate: 1}, 1000, 5), normal.CDF)
+
+	if result.PValue > 1e-6 {
+		t.Errorf("Failed to reject a sample from a different distribution, %v", result)
+	}
+
+	_, err = KolmogorovSmirnovTest(nil, uniform) // test for an empty sample
+
+	if err == nil {
+		t.Errorf("Function accepted an empty sample")
+	}
+}
+
+func TestAndersonDarlingTest(t *testing.T) {
+	uniform := Uniform{Min: 0, Max: 1}.CDF
+
+	result, err := AndersonDarlingTest([]float64{0.5}, uniform)
+	expected := 2*math.Ln2 - 1
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !almostEqual(result.Statistic, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result.Statistic)
+	}
+
+	// the asymptotic 5% and 1% critical values
+	if p := 1 - andersonDarlingCDF(2.492); !almostEqual(p, 0.05, 1e-3) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", 0.05, p)
+	}
+
+	if p := 1 - andersonDarlingCDF(3.857); !almostEqual(p, 0.01, 1e-3) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", 0.01, p)
+	}
+
+	g := Gamma{Shape: 3, Scale: 2}
+	result, _ = AndersonDarlingTest(drawSample(g, 1000, 6), g.CDF)
+
+	if result.PValue < 0.01 {
+		t.Errorf("Rejected a sample from the tested distribution, %v", result)
+	}
+
+	// a normal with the same mean and variance differs mostly in the tails
+	result, _ = AndersonDarlingTest(drawSample(g, 1000, 7), Normal{Mu: 6, Sigma: math.Sqrt(12)}.CDF)
+
+	if result.PValue > 1e-3 {
+		t.Errorf("Failed to reject a sample from a different distribution, %v", result)
+	}
+}
@@ -0,0 +1,259 @@
+package mlscratchlib
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// maxHistogramBins guards against a tiny bin width on a wide range
+// allocating an enormous histogram
+const maxHistogramBins = 1e7
+
+// Histogram holds the bins of a vector. Bin i covers
+// [Edges[i], Edges[i+1]), except the last bin which also includes its right
+// edge, so there is one more edge than there are bins. Densities are the
+// counts divided by the number of counted values and the bin width, so
+// they integrate to 1 like a probability density
+type Histogram struct {
+	Edges     []float64
+	Counts    []int
+	Densities []float64
+}
+
+// checkEdges returns an error unless edges has at least 2 elements in
+// strictly increasing order
+func checkEdges(edges []float64) error {
+	if len(edges) < 2 {
+		return errors.New("a histogram needs at least 2 edges")
+	}
+	for i := 1; i < len(edges); i++ {
+		if !(edges[i] > edges[i-1]) {
+			return errors.New("the edges must be strictly increasing")
+		}
+	}
+	return nil
+}
+
+// binIndex returns the bin of x for the given edges, or -1 when x is
+// outside of them
+func binIndex(edges []float64, x float64) int {
+	last := len(edges) - 1
+	if !(x >= edges[0] && x <= edges[last]) {
+		return -1
+	} else if x == edges[last] {
+		return last - 1
+	}
+	return sort.Search(len(edges), func(i int) bool { return edges[i] > x }) - 1
+}
+
+// HistogramWithEdges accepts a vector and the edges of the bins and
+// counts the values in each bin. Values outside of the edges aren't
+// counted
+func HistogramWithEdges(vector []float64, edges []float64) (*Histogram, error) {
+	if err := checkEdges(edges); err != nil {
+		return nil, err
+	}
+	h := &Histogram{
+		Edges:     copyVector(edges),
+		Counts:    make([]int, len(edges)-1),
+		Densities: make([]float64, len(edges)-1),
+	}
+	var total int
+	for _, x := range vector {
+		if i := binIndex(edges, x); i >= 0 {
+			h.Counts[i]++
+			total++
+		}
+	}
+	if total > 0 {
+		for i, count := range h.Counts {
+			h.Densities[i] = float64(count) / (float64(total) * (edges[i+1] - edges[i]))
+		}
+	}
+	return h, nil
+}
+
+// histogramRange returns the smallest and largest values of the vector,
+// widened to [x - 0.5, x + 0.5] when they are the same so that the bins
+// have a width
+func histogramRange(vector []float64) (low float64, high float64, err error) {
+	if len(vector) < 1 {
+		return 0, 0, errors.New("something went wrong vector length is 0")
+	}
+	low, high = vector[0], vector[0]
+	for _, x := range vector {
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return 0, 0, errors.New("every value must be finite")
+		}
+		low, high = math.Min(low, x), math.Max(high, x)
+	}
+	if low == high {
+		low, high = low-0.5, high+0.5
+	}
+	return low, high, nil
+}
+
+// FixedWidthHistogram accepts a vector and a bin width and counts the
+// values in bins of that width, starting at the smallest value
+func FixedWidthHistogram(vector []float64, width float64) (*Histogram, error) {
+	if !(width > 0) {
+		return nil, errors.New("the bin width must be greater than 0")
+	}
+	low, high, err := histogramRange(vector)
+	if err != nil {
+		return nil, err
+	}
+	bins := math.Max(1, math.Ceil((high-low)/width))
+	if bins > maxHistogramBins {
+		return nil, errors.New("the bin width is too small for the range of the values")
+	}
+	edges := make([]float64, int(bins)+1)
+	for i := range edges {
+		edges[i] = low + float64(i)*width
+	}
+	// rounding can leave the last edge just below the largest value
+	if edges[len(edges)-1] < high {
+		edges = append(edges, low+float64(len(edges))*width)
+	}
+	return HistogramWithEdges(vector, edges)
+}
+
+// FixedCountHistogram accepts a vector and a number of bins and counts the
+// values in that many bins of equal width spanning the values
+func FixedCountHistogram(vector []float64, bins int) (*Histogram, error) {
+	edges, err := equalWidthEdges(vector, bins)
+	if err != nil {
+		return nil, err
+	}
+	return HistogramWithEdges(vector, edges)
+}
+
+// equalWidthEdges returns the edges of bins equal width bins spanning the
+// values of the vector
+func equalWidthEdges(vector []float64, bins int) ([]float64, error) {
+	if bins < 1 {
+		return nil, errors.New("a histogram needs at least 1 bin")
+	}
+	low, high, err := histogramRange(vector)
+	if err != nil {
+		return nil, err
+	}
+	return gridPoints(low, high, bins+1)
+}
+
+// QuantileHistogram accepts a vector and a number of bins and places the
+// edges at evenly spaced quantiles, found with a single call to Quantiles
+// using QuantileType7, so that each bin holds about the same number of
+// values. Repeated values can make quantiles coincide, in which case the
+// histogram has fewer bins
+func QuantileHistogram(vector []float64, bins int) (*Histogram, error) {
+	if bins < 1 {
+		return nil, errors.New("a histogram needs at least 1 bin")
+	}
+	low, high, err := histogramRange(vector)
+	if err != nil {
+		return nil, err
+	}
+	percentiles := make([]float64, bins-1)
+	for i := range percentiles {
+		percentiles[i] = float64(i+1) / float64(bins)
+	}
+	quantiles, err := Quantiles(vector, percentiles, QuantileType7)
+	if err != nil {
+		return nil, err
+	}
+	edges := []float64{low}
+	for _, quantile := range quantiles {
+		if quantile > edges[len(edges)-1] && quantile < high {
+			edges = append(edges, quantile)
+		}
+	}
+	edges = append(edges, high)
+	return HistogramWithEdges(vector, edges)
+}
+
+// FreedmanDiaconisHistogram accepts a vector and counts the values in bins
+// of width 2 * IQR * n^(-1/3), using InterQuartileRangeVector. The rule
+// adapts to the spread of the data while ignoring outliers
+func FreedmanDiaconisHistogram(vector []float64) (*Histogram, error) {
+	if len(vector) < 2 {
+		return nil, errors.New("the Freedman-Diaconis rule needs at least 2 values")
+	}
+	width := 2 * InterQuartileRangeVector(vector) * math.Pow(float64(len(vector)), -1.0/3)
+	if width == 0 {
+		return nil, errors.New("the Freedman-Diaconis rule needs an interquartile range greater than 0")
+	}
+	return FixedWidthHistogram(vector, width)
+}
+
+// Histogram2D holds the bins of pairs of values. Counts[i][j] is the
+// number of pairs whose x is in bin i of XEdges and whose y is in bin j of
+// YEdges, with the same bin boundaries as Histogram. Densities divide the
+// counts by the number of counted pairs and the bin area
+type Histogram2D struct {
+	XEdges    []float64
+	YEdges    []float64
+	Counts    [][]int
+	Densities [][]float64
+}
+
+// Histogram2DWithEdges accepts the x and y values of pairs and the edges
+// of the bins along each and counts the pairs in each bin. Pairs outside
+// of the edges aren't counted
+func Histogram2DWithEdges(x []float64, y []float64, xEdges []float64, yEdges []float64) (*Histogram2D, error) {
+	if len(x) != len(y) {
+		return nil, errors.New("vectors must be the same length")
+	}
+	if err := checkEdges(xEdges); err != nil {
+		return nil, err
+	}
+	if err := checkEdges(yEdges); err != nil {
+		return nil, err
+	}
+	h := &Histogram2D{
+		XEdges:    copyVector(xEdges),
+		YEdges:    copyVector(yEdges),
+		Counts:    make([][]int, len(xEdges)-1),
+		Densities: make([][]float64, len(xEdges)-1),
+	}
+	for i := range h.Counts {
+		h.Counts[i] = make([]int, len(yEdges)-1)
+		h.Densities[i] = make([]float64, len(yEdges)-1)
+	}
+	var total int
+	for k := range x {
+		i, j := binIndex(xEdges, x[k]), binIndex(yEdges, y[k])
+		if i >= 0 && j >= 0 {
+			h.Counts[i][j]++
+			total++
+		}
+	}
+	if total > 0 {
+		for i := range h.Counts {
+			for j, count := range h.Counts[i] {
+				area := (xEdges[i+1] - xEdges[i]) * (yEdges[j+1] - yEdges[j])
+				h.Densities[i][j] = float64(count) / (float64(total) * area)
+			}
+		}
+	}
+	return h, nil
+}
+
+// FixedCountHistogram2D accepts the x and y values of pairs and the number
+// of bins along each, and counts the pairs in equal width bins spanning
+// the values
+func FixedCountHistogram2D(x []float64, y []float64, xBins int, yBins int) (*Histogram2D, error) {
+	if len(x) != len(y) {
+		return nil, errors.New("vectors must be the same length")
+	}
+	xEdges, err := equalWidthEdges(x, xBins)
+	if err != nil {
+		return nil, err
+	}
+	yEdges, err := equalWidthEdges(y, yBins)
+	if err != nil {
+		return nil, err
+	}
+	return Histogram2DWithEdges(x, y, xEdges, yEdges)
+}
@@ -0,0 +1,197 @@
+package mlscratchlib
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+var histogramData = []float64{1, 2, 2, 3, 3, 3, 4, 4, 5, 9}
+
+// histogramIntegral returns the sum of the densities times the bin widths
+func histogramIntegral(h *Histogram) float64 {
+	var sum float64
+	for i, density := range h.Densities {
+		sum += density * (h.Edges[i+1] - h.Edges[i])
+	}
+	return sum
+}
+
+func TestHistogramWithEdges(t *testing.T) {
+	h, err := HistogramWithEdges(histogramData, []float64{0, 2, 4, 5})
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	// 9 is outside the edges, and the last bin includes its right edge
+	expected := []int{1, 5, 3}
+	if !reflect.DeepEqual(h.Counts, expected) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, h.Counts)
+	}
+
+	expectedDensities := []float64{1.0 / 18, 5.0 / 18, 3.0 / 9}
+	if !vectorsAlmostEqual(h.Densities, expectedDensities, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expectedDensities, h.Densities)
+	}
+
+	_, err = HistogramWithEdges(histogramData, []float64{0, 2, 2}) // test the edge order logic
+
+	if err == nil {
+		t.Errorf("Function accepted edges that are not strictly increasing")
+	}
+}
+
+func TestFixedWidthHistogram(t *testing.T) {
+	h, err := FixedWidthHistogram(histogramData, 2)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	expectedEdges := []float64{1, 3, 5, 7, 9}
+	expected := []int{3, 5, 1, 1}
+	if !reflect.DeepEqual(h.Edges, expectedEdges) || !reflect.DeepEqual(h.Counts, expected) {
+		t.Errorf("Expected edges %v and counts %v, got %v and %v", expectedEdges, expected, h.Edges, h.Counts)
+	}
+
+	if result := histogramIntegral(h); !almostEqual(result, 1, 1e-12) {
+		t.Errorf("Expected the densities to integrate to 1, got %v", result)
+	}
+
+	// a width that doesn't divide the range adds a partial bin
+	h, _ = FixedWidthHistogram(histogramData, 3)
+
+	if result := h.Edges[len(h.Edges)-1]; result != 10 {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", 10, result)
+	}
+
+	_, err = FixedWidthHistogram(histogramData, 0) // test the width logic
+
+	if err == nil {
+		t.Errorf("Function accepted a bin width of 0")
+	}
+}
+
+func TestFixedCountHistogram(t *testing.T) {
+	h, err := FixedCountHistogram(histogramData, 4)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	expectedEdges := []float64{1, 3, 5, 7, 9}
+	expected := []int{3, 5, 1, 1}
+	if !reflect.DeepEqual(h.Edges, expectedEdges) || !reflect.DeepEqual(h.Counts, expected) {
+		t.Errorf("Expected edges %v and counts %v, got %v and %v", expectedEdges, expected, h.Edges, h.Counts)
+	}
+
+	// a single repeated value gets a bin of width 1 around it
+	h, _ = FixedCountHistogram([]float64{4, 4, 4}, 1)
+
+	if !reflect.DeepEqual(h.Edges, []float64{3.5, 4.5}) || h.Counts[0] != 3 {
+		t.Errorf("Expected one bin [3.5, 4.5] with 3 values, got %v and %v", h.Edges, h.Counts)
+	}
+
+	_, err = FixedCountHistogram(nil, 4) // test for an empty vector
+
+	if err == nil {
+		t.Errorf("Function accepted an empty vector")
+	}
+}
+
+func TestQuantileHistogram(t *testing.T) {
+	vector := drawSample(Exponential{Rate: 1}, 1000, 1)
+	h, err := QuantileHistogram(vector, 4)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	// every bin holds a quarter of the values despite the skew
+	for _, count := range h.Counts {
+		if count != 250 {
+			t.Errorf("Expected 250 values in every bin, got %v", h.Counts)
+			break
+		}
+	}
+
+	if result := histogramIntegral(h); !almostEqual(result, 1, 1e-12) {
+		t.Errorf("Expected the densities to integrate to 1, got %v", result)
+	}
+
+	// the inner edges are the quantiles of the same percentiles
+	quantiles, _ := Quantiles(vector, []float64{0.25, 0.5, 0.75}, QuantileType7)
+
+	if !reflect.DeepEqual(h.Edges[1:4], quantiles) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", quantiles, h.Edges[1:4])
+	}
+
+	// repeated values merge the quantiles at 0.25 and 0.5, which are both 1
+	repeated := []float64{1, 1, 1, 1, 1, 1, 2, 3}
+	h, _ = QuantileHistogram(repeated, 4)
+	quantiles, _ = Quantiles(repeated, []float64{0.25, 0.5, 0.75}, QuantileType7)
+	expectedEdges := []float64{1, quantiles[2], 3}
+
+	if quantiles[0] != 1 || quantiles[1] != 1 || !reflect.DeepEqual(h.Edges, expectedEdges) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v from quantiles %v", expectedEdges, h.Edges, quantiles)
+	}
+
+	if expected := []int{6, 2}; !reflect.DeepEqual(h.Counts, expected) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, h.Counts)
+	}
+}
+
+func TestFreedmanDiaconisHistogram(t *testing.T) {
+	vector := []float64{1, 2, 3, 4, 5, 6, 7, 8}
+	h, err := FreedmanDiaconisHistogram(vector)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	// the interquartile range is 4, so the width is 8 / 8^(1/3) = 4
+	expectedWidth := 2 * InterQuartileRangeVector(vector) * math.Pow(8, -1.0/3)
+	if result := h.Edges[1] - h.Edges[0]; !almostEqual(result, expectedWidth, 1e-12) || !almostEqual(result, 4, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expectedWidth, result)
+	}
+
+	_, err = FreedmanDiaconisHistogram([]float64{1, 5, 5, 5, 5, 5, 5, 9}) // test for an IQR of 0
+
+	if err == nil {
+		t.Errorf("Function accepted an interquartile range of 0")
+	}
+}
+
+func TestHistogram2D(t *testing.T) {
+	x := []float64{0, 1, 1, 2, 3, 4}
+	y := []float64{0, 0, 5, 5, 10, 10}
+	h, err := FixedCountHistogram2D(x, y, 2, 2)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	expected := [][]int{{2, 1}, {0, 3}}
+	if !reflect.DeepEqual(h.Counts, expected) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, h.Counts)
+	}
+
+	// the densities integrate to 1 over the bin areas
+	var sum float64
+	for i := range h.Densities {
+		for j, density := range h.Densities[i] {
+			sum += density * (h.XEdges[i+1] - h.XEdges[i]) * (h.YEdges[j+1] - h.YEdges[j])
+		}
+	}
+
+	if !almostEqual(sum, 1, 1e-12) {
+		t.Errorf("Expected the densities to integrate to 1, got %v", sum)
+	}
+
+	_, err = FixedCountHistogram2D(x, y[:3], 2, 2) // test the length mismatch logic
+
+	if err == nil {
+		t.Errorf("Function accepted vectors of mismatched lengths")
+	}
+}
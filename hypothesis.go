@@ -0,0 +1,197 @@
+package mlscratchlib
+
+import (
+	"errors"
+	"math"
+)
+
+// Alternative selects the alternative hypothesis of a test
+type Alternative int
+
+const (
+	// TwoSided tests whether the true value differs from the hypothesized one
+	TwoSided Alternative = iota
+	// Less tests whether the true value is less than the hypothesized one
+	Less
+	// Greater tests whether the true value is greater than the hypothesized one
+	Greater
+)
+
+// TestResult holds the outcome of a hypothesis test. Estimate is the
+// quantity being tested, such as a mean or a difference of means, and
+// Lower and Upper bound its confidence interval. One-sided tests have a
+// one-sided interval, so one of the bounds is infinite. DegreesOfFreedom
+// is +Inf for tests based on the normal distribution
+type TestResult struct {
+	Statistic        float64
+	DegreesOfFreedom float64
+	PValue           float64
+	Estimate         float64
+	Lower            float64
+	Upper            float64
+}
+
+// newTestResult fills in the p-value and confidence interval for a test
+// statistic that follows a t distribution with df degrees of freedom, or
+// a standard normal distribution when df is +Inf. The statistic is
+// (estimate - hypothesized) / standardError
+func newTestResult(statistic float64, df float64, estimate float64, standardError float64,
+	alternative Alternative, confidence float64) (TestResult, error) {
+	if !(confidence > 0 && confidence < 1) {
+		return TestResult{}, errors.New("confidence must be a decimal between 0 and 1")
+	}
+	result := TestResult{Statistic: statistic, DegreesOfFreedom: df, Estimate: estimate}
+	switch alternative {
+	case TwoSided:
+		result.PValue = 2 * StudentTCDF(-math.Abs(statistic), df)
+		critical := InverseStudentTCDF(1-(1-confidence)/2, df, 0)
+		result.Lower = estimate - critical*standardError
+		result.Upper = estimate + critical*standardError
+	case Less:
+		result.PValue = StudentTCDF(statistic, df)
+		result.Lower = math.Inf(-1)
+		result.Upper = estimate + InverseStudentTCDF(confidence, df, 0)*standardError
+	case Greater:
+		result.PValue = StudentTCDF(-statistic, df)
+		result.Lower = estimate - InverseStudentTCDF(confidence, df, 0)*standardError
+		result.Upper = math.Inf(1)
+	default:
+		return TestResult{}, errors.New("unknown alternative hypothesis")
+	}
+	return result, nil
+}
+
+// ZTest accepts a sample, a hypothesized mean and the known standard
+// deviation of the population and tests whether the sample mean differs
+// from the hypothesized mean. Confidence sets the level of the interval
+// around the sample mean, such as 0.95
+func ZTest(vector []float64, mean float64, sigma float64, alternative Alternative, confidence float64) (TestResult, error) {
+	if len(vector) < 1 {
+		return TestResult{}, errors.New("something went wrong vector length is 0")
+	} else if !(sigma > 0) {
+		return TestResult{}, errors.New("sigma must be greater than 0")
+	}
+	estimate := VectorMean(vector)
+	standardError := sigma / math.Sqrt(float64(len(vector)))
+	return newTestResult((estimate-mean)/standardError, math.Inf(1), estimate, standardError, alternative, confidence)
+}
+
+// OneSampleTTest accepts a sample and a hypothesized mean and tests whether
+// the sample mean differs from it, estimating the standard deviation from
+// the sample
+func OneSampleTTest(vector []float64, mean float64, alternative Alternative, confidence float64) (TestResult, error) {
+	if len(vector) < 2 {
+		return TestResult{}, errors.New("a t-test needs at least 2 values")
+	}
+	estimate := VectorMean(vector)
+	standardError := StandardDeviationVector(vector) / math.Sqrt(float64(len(vector)))
+	if standardError == 0 {
+		return TestResult{}, errors.New("the t statistic is undefined when the standard deviation is 0")
+	}
+	df := float64(len(vector) - 1)
+	return newTestResult((estimate-mean)/standardError, df, estimate, standardError, alternative, confidence)
+}
+
+// PairedTTest accepts two samples of matched pairs, such as measurements
+// of the same subjects before and after a change, and tests whether the
+// mean of the differences a - b is 0
+func PairedTTest(a []float64, b []float64, alternative Alternative, confidence float64) (TestResult, error) {
+	differences, err := SubtractVector(a, b)
+	if err != nil {
+		return TestResult{}, err
+	}
+	return OneSampleTTest(differences, 0, alternative, confidence)
+}
+
+// WelchTTest accepts two independent samples and tests whether their means
+// differ, without assuming that they have the same variance. The degrees
+// of freedom come from the Welch-Satterthwaite equation, and the estimate
+// is the difference of the means, mean(a) - mean(b)
+func WelchTTest(a []float64, b []float64, alternative Alternative, confidence float64) (TestResult, error) {
+	if len(a) < 2 || len(b) < 2 {
+		return TestResult{}, errors.New("a t-test needs at least 2 values in each sample")
+	}
+	aTerm := VarianceVector(a) / float64(len(a))
+	bTerm := VarianceVector(b) / float64(len(b))
+	standardError := math.Sqrt(aTerm + bTerm)
+	if standardError == 0 {
+		return TestResult{}, errors.New("the t statistic is undefined when the standard deviation is 0")
+	}
+	df := (aTerm + bTerm) * (aTerm + bTerm) /
+		(aTerm*aTerm/float64(len(a)-1) + bTerm*bTerm/float64(len(b)-1))
+	estimate := VectorMean(a) - VectorMean(b)
+	return newTestResult(estimate/standardError, df, estimate, standardError, alternative, confidence)
+}
+
+// checkProportion validates a count of successes out of a number of trials
+func checkProportion(successes int, trials int) error {
+	if trials < 1 {
+		return errors.New("the number of trials must be at least 1")
+	} else if successes < 0 || successes > trials {
+		return errors.New("successes must be between 0 and the number of trials")
+	}
+	return nil
+}
+
+// ProportionConfidenceInterval accepts a number of successes out of a
+// number of trials and returns the normal approximation (Wald) confidence
+// interval p ± z * sqrt(p * (1 - p) / n) for the true proportion, clamped
+// to [0, 1]. The approximation is poor when n*p or n*(1-p) is small
+func ProportionConfidenceInterval(successes int, trials int, confidence float64) (lower float64, upper float64, err error) {
+	if err := checkProportion(successes, trials); err != nil {
+		return 0, 0, err
+	}
+	if !(confidence > 0 && confidence < 1) {
+		return 0, 0, errors.New("confidence must be a decimal between 0 and 1")
+	}
+	p := float64(successes) / float64(trials)
+	standardError := math.Sqrt(p * (1 - p) / float64(trials))
+	z := InverseNormalCDF(1-(1-confidence)/2, 0, 1, 1e-12)
+	return math.Max(0, p-z*standardError), math.Min(1, p+z*standardError), nil
+}
+
+// ProportionZTest accepts a number of successes out of a number of trials
+// and tests whether the true proportion differs from the hypothesized
+// proportion, using the standard error under the null hypothesis
+func ProportionZTest(successes int, trials int, proportion float64, alternative Alternative, confidence float64) (TestResult, error) {
+	if err := checkProportion(successes, trials); err != nil {
+		return TestResult{}, err
+	}
+	if !(proportion > 0 && proportion < 1) {
+		return TestResult{}, errors.New("the hypothesized proportion must be between 0 and 1")
+	}
+	n := float64(trials)
+	estimate := float64(successes) / n
+	nullError := math.Sqrt(proportion * (1 - proportion) / n)
+	result, err := newTestResult((estimate-proportion)/nullError, math.Inf(1), estimate,
+		math.Sqrt(estimate*(1-estimate)/n), alternative, confidence)
+	if err != nil {
+		return TestResult{}, err
+	}
+	result.Lower, result.Upper = math.Max(0, result.Lower), math.Min(1, result.Upper)
+	return result, nil
+}
+
+// TwoProportionZTest accepts the successes and trials of two groups, such
+// as the conversions and visitors of the A and B arms of an experiment, and
+// tests whether their proportions differ. The statistic uses the pooled
+// proportion, and the estimate is the difference of the proportions a - b
+// with an unpooled confidence interval
+func TwoProportionZTest(aSuccesses int, aTrials int, bSuccesses int, bTrials int,
+	alternative Alternative, confidence float64) (TestResult, error) {
+	if err := checkProportion(aSuccesses, aTrials); err != nil {
+		return TestResult{}, err
+	}
+	if err := checkProportion(bSuccesses, bTrials); err != nil {
+		return TestResult{}, err
+	}
+	na, nb := float64(aTrials), float64(bTrials)
+	pa, pb := float64(aSuccesses)/na, float64(bSuccesses)/nb
+	pooled := float64(aSuccesses+bSuccesses) / (na + nb)
+	pooledError := math.Sqrt(pooled * (1 - pooled) * (1/na + 1/nb))
+	if pooledError == 0 {
+		return TestResult{}, errors.New("the z statistic is undefined when every trial has the same outcome")
+	}
+	standardError := math.Sqrt(pa*(1-pa)/na + pb*(1-pb)/nb)
+	return newTestResult((pa-pb)/pooledError, math.Inf(1), pa-pb, standardError, alternative, confidence)
+}
@@ -0,0 +1,213 @@
+package mlscratchlib
+
+import (
+	"math"
+	"testing"
+)
+
+func TestZTest(t *testing.T) {
+	// mean of vec8b is 4.5, sigma / sqrt(n) is 1
+	result, err := ZTest(vec8b, 3, math.Sqrt(8), TwoSided, 0.95)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !almostEqual(result.Statistic, 1.5, 1e-12) || !math.IsInf(result.DegreesOfFreedom, 1) {
+		t.Errorf("Expected a statistic of 1.5 with infinite degrees of freedom, got %v", result)
+	}
+
+	if !almostEqual(result.PValue, 0.13361440253771617, 1e-9) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", 0.13361440253771617, result.PValue)
+	}
+
+	if !almostEqual(result.Lower, 4.5-1.959963984540054, 1e-9) || !almostEqual(result.Upper, 4.5+1.959963984540054, 1e-9) {
+		t.Errorf("Unexpected confidence interval [%v, %v]", result.Lower, result.Upper)
+	}
+
+	// one-sided tests split the two-sided p-value and have half open intervals
+	greater, _ := ZTest(vec8b, 3, math.Sqrt(8), Greater, 0.95)
+	less, _ := ZTest(vec8b, 3, math.Sqrt(8), Less, 0.95)
+
+	if !almostEqual(greater.PValue, result.PValue/2, 1e-12) || !almostEqual(less.PValue, 1-result.PValue/2, 1e-12) {
+		t.Errorf("Expected one-sided p-values %v and %v, got %v and %v", result.PValue/2, 1-result.PValue/2, greater.PValue, less.PValue)
+	}
+
+	if !math.IsInf(greater.Upper, 1) || !math.IsInf(less.Lower, -1) {
+		t.Errorf("Expected half open intervals, got [%v, %v] and [%v, %v]", greater.Lower, greater.Upper, less.Lower, less.Upper)
+	}
+
+	_, err = ZTest(vec8b, 3, 0, TwoSided, 0.95) // test the sigma range logic
+
+	if err == nil {
+		t.Errorf("Function accepted a sigma of 0")
+	}
+
+	_, err = ZTest(vec8b, 3, 1, TwoSided, 95) // test the confidence range logic
+
+	if err == nil {
+		t.Errorf("Function accepted a confidence greater than 1")
+	}
+
+	_, err = ZTest(vec8b, 3, 1, Alternative(7), 0.95) // test for an unknown alternative
+
+	if err == nil {
+		t.Errorf("Function accepted an unknown alternative")
+	}
+}
+
+func TestOneSampleTTest(t *testing.T) {
+	// matches R's t.test(1:8, mu = 3)
+	result, err := OneSampleTTest(vec8b, 3, TwoSided, 0.95)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !almostEqual(result.Statistic, math.Sqrt(3), 1e-12) || result.DegreesOfFreedom != 7 {
+		t.Errorf("Expected t = 1.732 with 7 degrees of freedom, got %v", result)
+	}
+
+	if !almostEqual(result.PValue, 0.1269, 1e-4) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", 0.1269, result.PValue)
+	}
+
+	if !almostEqual(result.Lower, 2.452175, 1e-6) || !almostEqual(result.Upper, 6.547825, 1e-6) {
+		t.Errorf("Unexpected confidence interval [%v, %v]", result.Lower, result.Upper)
+	}
+
+	_, err = OneSampleTTest([]float64{2, 2, 2}, 3, TwoSided, 0.95) // test for zero variance
+
+	if err == nil {
+		t.Errorf("Function accepted a sample with a standard deviation of 0")
+	}
+
+	_, err = OneSampleTTest([]float64{2}, 3, TwoSided, 0.95) // test for a single value
+
+	if err == nil {
+		t.Errorf("Function accepted a single value")
+	}
+}
+
+func TestPairedTTest(t *testing.T) {
+	before := []float64{200.1, 190.9, 192.7, 213, 241.4, 196.9, 172.2, 185.5, 205.2, 193.7}
+	after := []float64{392.9, 393.2, 345.1, 393, 434, 427.9, 422, 383.9, 392.3, 352.2}
+
+	result, err := PairedTTest(after, before, Greater, 0.95)
+	differences, _ := SubtractVector(after, before)
+	expected, _ := OneSampleTTest(differences, 0, Greater, 0.95)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if result != expected {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	if result.PValue > 1e-6 {
+		t.Errorf("Expected a tiny p-value for a large consistent change, got %v", result.PValue)
+	}
+
+	_, err = PairedTTest(vec8a, vec10a, TwoSided, 0.95) // test the length mismatch logic
+
+	if err == nil {
+		t.Errorf("Function accepted samples of mismatched lengths")
+	}
+}
+
+func TestWelchTTest(t *testing.T) {
+	// mean 4.5 variance 6 against mean 7.5 variance 55/6
+	b := []float64{3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+
+	result, err := WelchTTest(vec8b, b, TwoSided, 0.95)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	standardError := math.Sqrt(6.0/8 + 55.0/60)
+	expectedDF := math.Pow(6.0/8+55.0/60, 2) / (math.Pow(6.0/8, 2)/7 + math.Pow(55.0/60, 2)/9)
+
+	if !almostEqual(result.Statistic, -3/standardError, 1e-12) || !almostEqual(result.DegreesOfFreedom, expectedDF, 1e-12) {
+		t.Errorf("Expected t = %v with %v degrees of freedom, got %v", -3/standardError, expectedDF, result)
+	}
+
+	if result.Estimate != -3 || result.Lower >= -3 || result.Upper <= -3 || result.Upper >= 0 {
+		t.Errorf("Unexpected estimate and interval %v [%v, %v]", result.Estimate, result.Lower, result.Upper)
+	}
+
+	if result.PValue < 0.02 || result.PValue > 0.05 {
+		t.Errorf("Expected a p-value between 0.02 and 0.05, got %v", result.PValue)
+	}
+}
+
+func TestProportionConfidenceInterval(t *testing.T) {
+	lower, upper, err := ProportionConfidenceInterval(50, 100, 0.95)
+	margin := 1.959963984540054 * 0.05
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !almostEqual(lower, 0.5-margin, 1e-9) || !almostEqual(upper, 0.5+margin, 1e-9) {
+		t.Errorf("Unexpected confidence interval [%v, %v]", lower, upper)
+	}
+
+	// the interval is clamped to valid proportions
+	lower, upper, err = ProportionConfidenceInterval(1, 10, 0.99)
+
+	if lower != 0 || upper >= 1 {
+		t.Errorf("Unexpected confidence interval [%v, %v]", lower, upper)
+	}
+
+	_, _, err = ProportionConfidenceInterval(11, 10, 0.95) // test for too many successes
+
+	if err == nil {
+		t.Errorf("Function accepted more successes than trials")
+	}
+}
+
+func TestProportionZTest(t *testing.T) {
+	// 60 heads in 100 flips of a fair coin is z = 2
+	result, err := ProportionZTest(60, 100, 0.5, TwoSided, 0.95)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !almostEqual(result.Statistic, 2, 1e-12) || !almostEqual(result.PValue, 0.04550026389635842, 1e-9) {
+		t.Errorf("Expected z = 2 with p = 0.0455, got %v", result)
+	}
+
+	_, err = ProportionZTest(60, 100, 1, TwoSided, 0.95) // test the proportion range logic
+
+	if err == nil {
+		t.Errorf("Function accepted a hypothesized proportion of 1")
+	}
+}
+
+func TestTwoProportionZTest(t *testing.T) {
+	// 200 of 1000 against 150 of 1000 conversions
+	result, err := TwoProportionZTest(200, 1000, 150, 1000, TwoSided, 0.95)
+	pooled := 350.0 / 2000
+	expected := 0.05 / math.Sqrt(pooled*(1-pooled)*(2.0/1000))
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !almostEqual(result.Statistic, expected, 1e-12) || !almostEqual(result.Estimate, 0.05, 1e-12) {
+		t.Errorf("Expected z = %v for a difference of 0.05, got %v", expected, result)
+	}
+
+	if result.PValue > 0.01 || result.Lower <= 0 {
+		t.Errorf("Expected a significant difference, got %v", result)
+	}
+
+	_, err = TwoProportionZTest(0, 10, 0, 10, TwoSided, 0.95) // test for identical outcomes
+
+	if err == nil {
+		t.Errorf("Function accepted groups where every trial has the same outcome")
+	}
+}
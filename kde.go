@@ -0,0 +1,289 @@
+package mlscratchlib
+
+import (
+	"errors"
+	"math"
+)
+
+// Kernel selects the shape of the bump that a kernel density estimate
+// places on every data point
+type Kernel int
+
+const (
+	// GaussianKernel is the standard normal density, which is smooth and has
+	// unbounded support
+	GaussianKernel Kernel = iota
+	// EpanechnikovKernel is 3/4 * (1 - u^2) on [-1, 1], which minimizes the
+	// asymptotic mean integrated squared error
+	EpanechnikovKernel
+	// UniformKernel is 1/2 on [-1, 1), giving a moving window count
+	UniformKernel
+)
+
+// density returns the value of the kernel at u, for a kernel scaled to a
+// bandwidth of 1
+func (k Kernel) density(u float64) (float64, error) {
+	switch k {
+	case GaussianKernel:
+		return NormalProbabilityDistribution(u, 0, 1), nil
+	case EpanechnikovKernel:
+		if math.Abs(u) > 1 {
+			return 0, nil
+		}
+		return 0.75 * (1 - u*u), nil
+	case UniformKernel:
+		// map [-1, 1) onto the [0, 1) of UniformProbabilityDistribution
+		return UniformProbabilityDistribution((u+1)/2) / 2, nil
+	}
+	return 0, errors.New("unknown kernel")
+}
+
+// bandwidthSpread returns min(standard deviation, IQR / 1.34), the robust
+// measure of spread used by the rule of thumb bandwidths. It falls back to
+// the standard deviation when the interquartile range is 0
+func bandwidthSpread(vector []float64) (float64, error) {
+	if len(vector) < 2 {
+		return 0, errors.New("a bandwidth needs at least 2 values")
+	}
+	spread := StandardDeviationVector(vector)
+	if iqr := InterQuartileRangeVector(vector) / 1.34; iqr > 0 {
+		spread = math.Min(spread, iqr)
+	}
+	if spread == 0 {
+		return 0, errors.New("the bandwidth is 0 when every value is the same")
+	}
+	return spread, nil
+}
+
+// SilvermanBandwidth accepts a sample and returns Silverman's rule of
+// thumb bandwidth 0.9 * min(standard deviation, IQR / 1.34) * n^(-1/5) for
+// a Gaussian kernel. It is a good default for unimodal data and tends to
+// oversmooth data with several modes
+func SilvermanBandwidth(vector []float64) (float64, error) {
+	spread, err := bandwidthSpread(vector)
+	if err != nil {
+		return 0, err
+	}
+	return 0.9 * spread * math.Pow(float64(len(vector)), -0.2), nil
+}
+
+// ScottBandwidth accepts a sample and returns Scott's rule bandwidth
+// 1.06 * min(standard deviation, IQR / 1.34) * n^(-1/5), which is optimal
+// for a Gaussian kernel when the data is normal
+func ScottBandwidth(vector []float64) (float64, error) {
+	spread, err := bandwidthSpread(vector)
+	if err != nil {
+		return 0, err
+	}
+	return 1.06 * spread * math.Pow(float64(len(vector)), -0.2), nil
+}
+
+// multivariateBandwidths returns the standard deviation of each column of
+// the data times factor(n, d)
+func multivariateBandwidths(data [][]float64, factor func(n float64, d float64) float64) ([]float64, error) {
+	if len(data) < 2 {
+		return nil, errors.New("a bandwidth needs at least 2 values")
+	}
+	d := len(data[0])
+	if d < 1 {
+		return nil, errors.New("something went wrong, data has 0 columns")
+	}
+	for _, row := range data {
+		if len(row) != d {
+			return nil, errors.New("every row of the matrix must have the same number of elements")
+		}
+	}
+	bandwidths := make([]float64, d)
+	for j := range bandwidths {
+		column, err := GetColumn(data, j)
+		if err != nil {
+			return nil, err
+		}
+		bandwidths[j] = StandardDeviationVector(column) * factor(float64(len(data)), float64(d))
+		if bandwidths[j] == 0 {
+			return nil, errors.New("the bandwidth is 0 when every value in a column is the same")
+		}
+	}
+	return bandwidths, nil
+}
+
+// MultivariateSilvermanBandwidths accepts a data matrix where each row is
+// an observation and returns a bandwidth for each column, its standard
+// deviation times (4 / ((d + 2) * n))^(1 / (d + 4))
+func MultivariateSilvermanBandwidths(data [][]float64) ([]float64, error) {
+	return multivariateBandwidths(data, func(n float64, d float64) float64 {
+		return math.Pow(4/((d+2)*n), 1/(d+4))
+	})
+}
+
+// MultivariateScottBandwidths accepts a data matrix where each row is an
+// observation and returns a bandwidth for each column, its standard
+// deviation times n^(-1 / (d + 4))
+func MultivariateScottBandwidths(data [][]float64) ([]float64, error) {
+	return multivariateBandwidths(data, func(n float64, d float64) float64 {
+		return math.Pow(n, -1/(d+4))
+	})
+}
+
+// KDE is a one dimensional kernel density estimate, a smooth alternative
+// to a histogram that averages a kernel centered on every data point
+type KDE struct {
+	data      []float64
+	kernel    Kernel
+	bandwidth float64
+}
+
+// NewKDE accepts a sample, a kernel and a bandwidth, such as the one from
+// SilvermanBandwidth, and returns the kernel density estimate
+func NewKDE(vector []float64, kernel Kernel, bandwidth float64) (*KDE, error) {
+	if len(vector) < 1 {
+		return nil, errors.New("something went wrong vector length is 0")
+	} else if !(bandwidth > 0) {
+		return nil, errors.New("the bandwidth must be greater than 0")
+	} else if _, err := kernel.density(0); err != nil {
+		return nil, err
+	}
+	return &KDE{data: copyVector(vector), kernel: kernel, bandwidth: bandwidth}, nil
+}
+
+// Bandwidth returns the bandwidth of the estimate
+func (k *KDE) Bandwidth() float64 {
+	return k.bandwidth
+}
+
+// Density returns the estimated density at x, the mean over the data of
+// kernel((x - value) / bandwidth) / bandwidth
+func (k *KDE) Density(x float64) float64 {
+	var sum float64
+	for _, value := range k.data {
+		// the kernel was checked in NewKDE
+		density, _ := k.kernel.density((x - value) / k.bandwidth)
+		sum += density
+	}
+	return sum / (float64(len(k.data)) * k.bandwidth)
+}
+
+// Grid returns points evenly spaced numbers from low to high and the
+// estimated density at each of them, ready to plot as a curve
+func (k *KDE) Grid(low float64, high float64, points int) (xs []float64, densities []float64, err error) {
+	xs, err = gridPoints(low, high, points)
+	if err != nil {
+		return nil, nil, err
+	}
+	densities = make([]float64, points)
+	for i, x := range xs {
+		densities[i] = k.Density(x)
+	}
+	return xs, densities, nil
+}
+
+// gridPoints returns points evenly spaced numbers from low to high
+func gridPoints(low float64, high float64, points int) ([]float64, error) {
+	if points < 2 {
+		return nil, errors.New("a grid needs at least 2 points")
+	} else if !(high > low) {
+		return nil, errors.New("the top of the grid must be greater than the bottom")
+	}
+	xs := make([]float64, points)
+	step := (high - low) / float64(points-1)
+	for i := range xs {
+		xs[i] = low + float64(i)*step
+	}
+	xs[points-1] = high
+	return xs, nil
+}
+
+// MultivariateKDE is a kernel density estimate over vectors that uses a
+// product kernel, multiplying a one dimensional kernel for each variable
+// with its own bandwidth
+type MultivariateKDE struct {
+	data       [][]float64
+	kernel     Kernel
+	bandwidths []float64
+}
+
+// NewMultivariateKDE accepts a data matrix where each row is an
+// observation, a kernel and a bandwidth for each column, such as the ones
+// from MultivariateScottBandwidths, and returns the kernel density estimate
+func NewMultivariateKDE(data [][]float64, kernel Kernel, bandwidths []float64) (*MultivariateKDE, error) {
+	if len(data) < 1 {
+		return nil, errors.New("something went wrong, data has 0 rows")
+	}
+	for _, row := range data {
+		if len(row) != len(bandwidths) {
+			return nil, errors.New("every row must have one element for every bandwidth")
+		}
+	}
+	for _, bandwidth := range bandwidths {
+		if !(bandwidth > 0) {
+			return nil, errors.New("the bandwidth must be greater than 0")
+		}
+	}
+	if _, err := kernel.density(0); err != nil {
+		return nil, err
+	}
+	copied := make([][]float64, len(data))
+	for i, row := range data {
+		copied[i] = copyVector(row)
+	}
+	return &MultivariateKDE{data: copied, kernel: kernel, bandwidths: copyVector(bandwidths)}, nil
+}
+
+// Bandwidths returns a copy of the bandwidth of each variable
+func (k *MultivariateKDE) Bandwidths() []float64 {
+	return copyVector(k.bandwidths)
+}
+
+// Density returns the estimated density at x
+func (k *MultivariateKDE) Density(x []float64) (float64, error) {
+	if len(x) != len(k.bandwidths) {
+		return 0, errors.New("the vector must have one element for every bandwidth")
+	}
+	volume := 1.0
+	for _, bandwidth := range k.bandwidths {
+		volume *= bandwidth
+	}
+	var sum float64
+	for _, row := range k.data {
+		product := 1.0
+		for j, value := range row {
+			density, _ := k.kernel.density((x[j] - value) / k.bandwidths[j])
+			product *= density
+			if product == 0 {
+				break
+			}
+		}
+		sum += product
+	}
+	return sum / (float64(len(k.data)) * volume), nil
+}
+
+// Grid accepts the grid coordinates along each variable, such as ones from
+// evenly spaced points, and returns the estimated density at every
+// combination of them in row-major order, so that for two variables the
+// density at (axes[0][i], axes[1][j]) is element i*len(axes[1]) + j
+func (k *MultivariateKDE) Grid(axes [][]float64) ([]float64, error) {
+	if len(axes) != len(k.bandwidths) {
+		return nil, errors.New("there must be one axis for every bandwidth")
+	}
+	total := 1
+	for _, axis := range axes {
+		total *= len(axis)
+	}
+	densities := make([]float64, total)
+	point := make([]float64, len(axes))
+	for index := range densities {
+		// decode the row-major index into one coordinate per axis
+		remainder := index
+		for j := len(axes) - 1; j >= 0; j-- {
+			point[j] = axes[j][remainder%len(axes[j])]
+			remainder /= len(axes[j])
+		}
+		density, err := k.Density(point)
+		if err != nil {
+			return nil, err
+		}
+		densities[index] = density
+	}
+	return densities, nil
+}
@@ -0,0 +1,203 @@
+package mlscratchlib
+
+import (
+	"math"
+	"testing"
+)
+
+func TestKernels(t *testing.T) {
+	// every kernel integrates to 1
+	for _, kernel := range []Kernel{GaussianKernel, EpanechnikovKernel, UniformKernel} {
+		kde, err := NewKDE([]float64{0}, kernel, 1)
+		if err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+		xs, densities, _ := kde.Grid(-8, 8, 16001)
+		var integral float64
+		for i := range xs {
+			integral += densities[i] * 0.001
+		}
+
+		if !almostEqual(integral, 1, 1e-3) {
+			t.Errorf("Kernel %v integrates to %v", kernel, integral)
+		}
+	}
+
+	_, err := NewKDE([]float64{0}, Kernel(7), 1) // test for an unknown kernel
+
+	if err == nil {
+		t.Errorf("Function accepted an unknown kernel")
+	}
+}
+
+func TestKDEDensity(t *testing.T) {
+	var expected, result float64
+
+	// a single point with a Gaussian kernel is a normal density
+	kde, _ := NewKDE([]float64{1}, GaussianKernel, 2)
+	result = kde.Density(2.5)
+	expected = Normal{Mu: 1, Sigma: 2}.PDF(2.5)
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	kde, _ = NewKDE([]float64{0, 4}, EpanechnikovKernel, 2)
+	result = kde.Density(1)
+	expected = 0.75 * 0.75 / 4
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	kde, _ = NewKDE([]float64{0, 0.5}, UniformKernel, 2)
+	result = kde.Density(2.2)
+	expected = 0.125
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	// a Gaussian estimate from normal data is on average the normal density
+	// widened by the bandwidth
+	vector := drawSample(Normal{Mu: 0, Sigma: 1}, 20000, 1)
+	bandwidth, _ := SilvermanBandwidth(vector)
+	kde, _ = NewKDE(vector, GaussianKernel, bandwidth)
+	result = kde.Density(0)
+	expected = NormalProbabilityDistribution(0, 0, math.Sqrt(1+bandwidth*bandwidth))
+
+	if math.Abs(result-expected) > 0.02 {
+		t.Errorf("Expected result near:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	_, err := NewKDE(vector, GaussianKernel, 0) // test the bandwidth logic
+
+	if err == nil {
+		t.Errorf("Function accepted a bandwidth of 0")
+	}
+
+	_, _, err = kde.Grid(1, 0, 10) // test the grid range logic
+
+	if err == nil {
+		t.Errorf("Function accepted a grid from 1 down to 0")
+	}
+}
+
+func TestBandwidths(t *testing.T) {
+	var expected, result float64
+	vector := []float64{1, 2, 3, 4, 5, 6, 7, 8}
+
+	// the standard deviation sqrt(6) is smaller than IQR / 1.34 = 4 / 1.34
+	result, _ = SilvermanBandwidth(vector)
+	expected = 0.9 * math.Sqrt(6) * math.Pow(8, -0.2)
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	result, _ = ScottBandwidth(vector)
+	expected = 1.06 * math.Sqrt(6) * math.Pow(8, -0.2)
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	// an outlier inflates the standard deviation but not the IQR
+	result, _ = SilvermanBandwidth([]float64{1, 2, 3, 4, 5, 6, 7, 800})
+	expected = 0.9 * (4 / 1.34) * math.Pow(8, -0.2)
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	_, err := ScottBandwidth([]float64{2, 2, 2}) // test for zero spread
+
+	if err == nil {
+		t.Errorf("Function accepted a sample with a standard deviation of 0")
+	}
+
+	// the multivariate rules for one variable
+	data := [][]float64{{1}, {2}, {3}, {4}, {5}, {6}, {7}, {8}}
+	bandwidths, _ := MultivariateScottBandwidths(data)
+	expected = math.Sqrt(6) * math.Pow(8, -0.2)
+
+	if !vectorsAlmostEqual(bandwidths, []float64{expected}, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, bandwidths)
+	}
+
+	bandwidths, _ = MultivariateSilvermanBandwidths(data)
+	expected = math.Sqrt(6) * math.Pow(4.0/(3*8), 0.2)
+
+	if !vectorsAlmostEqual(bandwidths, []float64{expected}, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, bandwidths)
+	}
+
+	// wide data with more variables than observations, each column has a
+	// standard deviation of |d| / sqrt(2) for the row differences d
+	bandwidths, err = MultivariateScottBandwidths([][]float64{{1, 2, 3, 4}, {2, 5, 1, 0}})
+	factor := math.Pow(2, -1.0/8) / math.Sqrt2
+	expectedBandwidths := []float64{factor, 3 * factor, 2 * factor, 4 * factor}
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !vectorsAlmostEqual(bandwidths, expectedBandwidths, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expectedBandwidths, bandwidths)
+	}
+
+	_, err = MultivariateScottBandwidths([][]float64{{1, 2}, {3}, {4, 5}}) // test for ragged rows
+
+	if err == nil {
+		t.Errorf("Function accepted ragged rows")
+	}
+}
+
+func TestMultivariateKDE(t *testing.T) {
+	// a single point is a product of normal densities
+	kde, err := NewMultivariateKDE([][]float64{{0, 0}}, GaussianKernel, []float64{1, 2})
+
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	axes := [][]float64{{0, 1}, {0, 1, 2}}
+	densities, err := kde.Grid(axes)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	for i, x := range axes[0] {
+		for j, y := range axes[1] {
+			expected := NormalProbabilityDistribution(x, 0, 1) * Normal{Mu: 0, Sigma: 2}.PDF(y)
+
+			if !almostEqual(densities[i*3+j], expected, 1e-12) {
+				t.Errorf("(%v, %v)\nExpected result of:\n%v\ngot result:\n%v", x, y, expected, densities[i*3+j])
+			}
+		}
+	}
+
+	// one variable matches the one dimensional estimate
+	vector := []float64{0.5, 1.2, 3, 3.1, 4.8}
+	data := [][]float64{{0.5}, {1.2}, {3}, {3.1}, {4.8}}
+	single, _ := NewKDE(vector, EpanechnikovKernel, 1.5)
+	multiple, _ := NewMultivariateKDE(data, EpanechnikovKernel, []float64{1.5})
+	result, _ := multiple.Density([]float64{2.2})
+
+	if !almostEqual(result, single.Density(2.2), 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", single.Density(2.2), result)
+	}
+
+	_, err = NewMultivariateKDE(data, GaussianKernel, []float64{1, 1}) // test the dimension logic
+
+	if err == nil {
+		t.Errorf("Function accepted rows that don't match the bandwidths")
+	}
+
+	_, err = multiple.Density([]float64{1, 2}) // test the length mismatch logic
+
+	if err == nil {
+		t.Errorf("Function accepted a vector of the wrong length")
+	}
+}
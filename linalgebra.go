@@ -2,6 +2,7 @@ package mlscratchlib
 
 import (
 	"errors"
+	"fmt"
 	"math"
 	"sort"
 )
@@ -28,8 +29,16 @@ func VectorMean(vector []float64) (meanValue float64) {
 // VectorMedian accepts a []float64 vector and returns the element
 // which is in the middle most index when the vector is sorted from
 // low to high if there are an odd number of elements and the average
-// of the two middle-most elements if the number of elements is even
+// of the two middle-most elements if the number of elements is even.
+// The caller's vector is left in its original order
 func VectorMedian(vector []float64) (medianValue float64) {
+	return VectorMedianInPlace(copyVector(vector))
+}
+
+// VectorMedianInPlace works like VectorMedian but sorts the given
+// vector instead of a copy of it, which saves an allocation when the
+// caller doesn't need to keep the original order
+func VectorMedianInPlace(vector []float64) (medianValue float64) {
 	if len(vector) < 1 {
 		return 0
 	}
@@ -49,9 +58,64 @@ func VectorMedian(vector []float64) (medianValue float64) {
 	return average
 }
 
+// VectorMedianSelect returns the same value as VectorMedian but finds
+// the middle elements with quickselect, which takes O(n) time on
+// average instead of the O(n log n) of a full sort
+func VectorMedianSelect(vector []float64) (medianValue float64) {
+	if len(vector) < 1 {
+		return 0
+	}
+	work := copyVector(vector)
+	high := len(work) / 2
+	upper := selectKth(work, high)
+	if len(work)%2 != 0 {
+		return upper
+	}
+
+	// after selecting, everything below high is <= upper so the other
+	// middle element is the largest of them
+	lower := work[0]
+	for _, element := range work[1:high] {
+		lower = math.Max(lower, element)
+	}
+	return (lower + upper) / 2
+}
+
 // QuantileVector accepts a vector []float64 and a decimal to represent the percentile
-// of the element that you want to return.
+// of the element that you want to return. The caller's vector is left
+// in its original order. It returns the element at index
+// int(percentile * len(vector)) without interpolating, use Quantile to
+// pick one of the standard interpolation methods
 func QuantileVector(vector []float64, percentile float64) (quantile float64, err error) {
+	return QuantileVectorInPlace(copyVector(vector), percentile)
+}
+
+// QuantileVectorInPlace works like QuantileVector but sorts the given
+// vector instead of a copy of it
+func QuantileVectorInPlace(vector []float64, percentile float64) (quantile float64, err error) {
+	index, err := quantileIndex(vector, percentile)
+	if err != nil {
+		return 0, err
+	}
+	sort.Float64s(vector)
+
+	return vector[index], nil
+}
+
+// QuantileVectorSelect returns the same value as QuantileVector but
+// finds the element with quickselect in O(n) average time, which is
+// faster than sorting when only a single quantile is needed
+func QuantileVectorSelect(vector []float64, percentile float64) (quantile float64, err error) {
+	index, err := quantileIndex(vector, percentile)
+	if err != nil {
+		return 0, err
+	}
+	return selectKth(copyVector(vector), index), nil
+}
+
+// quantileIndex validates the arguments to the QuantileVector functions
+// and returns the index of the quantile in the sorted vector
+func quantileIndex(vector []float64, percentile float64) (int, error) {
 	if len(vector) < 1 {
 		return 0, errors.New("something went wrong vector length is 0")
 	}
@@ -61,9 +125,54 @@ func QuantileVector(vector []float64, percentile float64) (quantile float64, err
 		return 0, errors.New("percentile must be a decimal between 0 and 1")
 	}
 	index := int(percentile * float64(len(vector)))
-	sort.Float64s(vector)
+	if index == len(vector) {
+		// a percentile of 1 is the largest element
+		index = len(vector) - 1
+	}
+	return index, nil
+}
 
-	return vector[index], nil
+// selectKth reorders vector in place so that the element at index k is
+// the one that would be there if the vector were sorted, everything
+// before it is <= and everything after it is >=, and returns it
+func selectKth(vector []float64, k int) float64 {
+	low, high := 0, len(vector)-1
+	for low < high {
+		// median of three pivot so sorted input doesn't degrade to O(n^2)
+		a, b, c := vector[low], vector[low+(high-low)/2], vector[high]
+		pivot := math.Max(math.Min(a, b), math.Min(math.Max(a, b), c))
+
+		// three way partition into < pivot, == pivot and > pivot
+		less, i, greater := low, low, high
+		for i <= greater {
+			if vector[i] < pivot {
+				vector[less], vector[i] = vector[i], vector[less]
+				less++
+				i++
+			} else if vector[i] > pivot {
+				vector[i], vector[greater] = vector[greater], vector[i]
+				greater--
+			} else {
+				i++
+			}
+		}
+
+		if k < less {
+			high = less - 1
+		} else if k > greater {
+			low = greater + 1
+		} else {
+			return vector[k]
+		}
+	}
+	return vector[k]
+}
+
+// copyVector returns a new vector with the same elements as vector
+func copyVector(vector []float64) []float64 {
+	result := make([]float64, len(vector))
+	copy(result, vector)
+	return result
 }
 
 // ModeVector accepts a vector []float64 and returns a slice of the most common
@@ -96,13 +205,18 @@ func ModeVector(vector []float64) (mode []float64, err error) {
 }
 
 // RangeVector accepts a vector and returns a float64 that represents the
-// difference between the highest and the lowest values in the vector
+// difference between the highest and the lowest values in the vector.
+// It finds them in a single pass without reordering the vector
 func RangeVector(vector []float64) float64 {
 	if len(vector) < 1 {
 		return 0
 	}
-	sort.Float64s(vector)
-	return vector[len(vector)-1] - vector[0]
+	low, high := vector[0], vector[0]
+	for _, element := range vector[1:] {
+		low = math.Min(low, element)
+		high = math.Max(high, element)
+	}
+	return high - low
 }
 
 // AddVector returns a new vector whose elements are the sum of each
@@ -129,6 +243,30 @@ func SubtractVector(a []float64, b []float64) (vector []float64, err error) {
 	return vector, nil
 }
 
+// AddVectorTo stores the sum of each element of the given vectors a and
+// b in dst without allocating. dst may be the same slice as a or b
+func AddVectorTo(dst []float64, a []float64, b []float64) error {
+	if len(a) != len(b) || len(dst) != len(a) {
+		return errors.New("the vectors must have the same number of elements")
+	}
+	for i := range a {
+		dst[i] = a[i] + b[i]
+	}
+	return nil
+}
+
+// SubtractVectorTo stores the difference between vector a and vector b
+// in dst without allocating. dst may be the same slice as a or b
+func SubtractVectorTo(dst []float64, a []float64, b []float64) error {
+	if len(a) != len(b) || len(dst) != len(a) {
+		return errors.New("the vectors must have the same number of elements")
+	}
+	for i := range a {
+		dst[i] = a[i] - b[i]
+	}
+	return nil
+}
+
 // SumVectors takes a slice of vectors and returns a new vector
 // the elements of which are the componentwise sum of the slice of
 // vectors. example: vec1 = {1, 2, 3} vec2 = {4, 5, 6} vec3 = {7, 8, 9}
@@ -154,6 +292,31 @@ func ScalarMultiply(num float64, v []float64) (vector []float64) {
 	return vector
 }
 
+// ScalarMultiplyTo stores the product of num and each element of v in
+// dst without allocating. dst may be the same slice as v
+func ScalarMultiplyTo(dst []float64, num float64, v []float64) error {
+	if len(dst) != len(v) {
+		return errors.New("the vectors must have the same number of elements")
+	}
+	for i, element := range v {
+		dst[i] = element * num
+	}
+	return nil
+}
+
+// AXPY adds alpha times each element of x to the matching element of
+// dst in place, ie. dst += alpha*x, which is the inner step of most
+// gradient descent updates
+func AXPY(dst []float64, alpha float64, x []float64) error {
+	if len(dst) != len(x) {
+		return errors.New("the vectors must have the same number of elements")
+	}
+	for i, element := range x {
+		dst[i] += alpha * element
+	}
+	return nil
+}
+
 // MeanVector accepts a slice of vectors, sums each element of each
 // vector and returns the mean vector
 func MeanVector(vectors []([]float64)) (vector []float64, err error) {
@@ -258,6 +421,19 @@ func DeMeanVector(vector []float64) (result []float64) {
 	return result
 }
 
+// DeMeanVectorTo stores each element of vector minus the mean of vector
+// in dst without allocating. dst may be the same slice as vector
+func DeMeanVectorTo(dst []float64, vector []float64) error {
+	if len(dst) != len(vector) {
+		return errors.New("the vectors must have the same number of elements")
+	}
+	mean := VectorMean(vector)
+	for i, element := range vector {
+		dst[i] = element - mean
+	}
+	return nil
+}
+
 // VarianceVector accepts a vector, returns a float that represents the
 // amount of variance there is in the data within the vector
 func VarianceVector(vector []float64) float64 {
@@ -299,6 +475,10 @@ func StandardDeviationVector(vector []float64) float64 {
 // 0 represents no correlation ie no ***LINEAR*** relationship
 // 1 represents perfect positive correlation
 // -1 represents perfect negative correlation
+// If either vector has a standard deviation of 0 the correlation is
+// undefined and an error is returned. Earlier versions returned 0 with a
+// nil error in that case, so callers that relied on that need to check
+// the error
 func Correlation(a []float64, b []float64) (float64, error) {
 	if len(a) != len(b) {
 		return 0, errors.New("vectors must be the same length")
@@ -318,15 +498,21 @@ func Correlation(a []float64, b []float64) (float64, error) {
 	}
 
 	// one of the vectors has a std deviation of 0
-	return 0, nil
+	return 0, errors.New("correlation is undefined when a vector has a standard deviation of 0")
 }
 
 // InterQuartileRangeVector accepts a vector, calculates the
 // quantile for the 25 and 75 percentiles and returns the difference
-// as a float.
+// as a float. The caller's vector is left in its original order
 func InterQuartileRangeVector(vector []float64) float64 {
-	upper, _ := QuantileVector(vector, 0.75)
-	lower, _ := QuantileVector(vector, 0.25)
+	return InterQuartileRangeVectorInPlace(copyVector(vector))
+}
+
+// InterQuartileRangeVectorInPlace works like InterQuartileRangeVector
+// but sorts the given vector instead of a copy of it
+func InterQuartileRangeVectorInPlace(vector []float64) float64 {
+	upper, _ := QuantileVectorInPlace(vector, 0.75)
+	lower, _ := QuantileVectorInPlace(vector, 0.25)
 	return upper - lower
 }
 
@@ -354,10 +540,13 @@ func GetRow(matrix [][]float64, number int) (row []float64, err error) {
 // returns a vector whose elements are the element of the nth
 // index of each row
 func GetColumn(matrix [][]float64, number int) (column []float64, err error) {
-	if number > len(matrix) {
+	if number < 0 || len(matrix) == 0 || number >= len(matrix[0]) {
 		return nil, errors.New("Index out of range.")
 	}
 	for _, r := range matrix {
+		if number >= len(r) {
+			return nil, errors.New("Index out of range.")
+		}
 		column = append(column, r[number])
 	}
 
@@ -387,3 +576,83 @@ func IsDiagonal(x int, y int) float64 {
 	}
 	return 0
 }
+
+// CovarianceMatrix accepts a data matrix where each row is an observation
+// and each column is a variable, and returns the p x p matrix whose
+// element (i, j) is the covariance of column i and column j. When
+// population is true the deviations are divided by the number of rows,
+// otherwise by the number of rows minus one like Covariance
+func CovarianceMatrix(data [][]float64, population bool) (*Matrix, error) {
+	deviations, divisor, err := deMeanedColumns(data, population)
+	if err != nil {
+		return nil, err
+	}
+	p := len(deviations)
+	covariance := NewMatrix(p, p)
+	for i := 0; i < p; i++ {
+		for j := i; j < p; j++ {
+			sum, err := DotProduct(deviations[i], deviations[j])
+			if err != nil {
+				return nil, err
+			}
+			covariance.data[i*p+j] = sum / divisor
+			covariance.data[j*p+i] = sum / divisor
+		}
+	}
+	return covariance, nil
+}
+
+// CorrelationMatrix accepts a data matrix where each row is an observation
+// and each column is a variable, and returns the p x p matrix whose
+// element (i, j) is the correlation of column i and column j. Every
+// column must have a standard deviation greater than zero
+func CorrelationMatrix(data [][]float64) (*Matrix, error) {
+	// the divisor cancels out of the correlation so either normalization works
+	covariance, err := CovarianceMatrix(data, false)
+	if err != nil {
+		return nil, err
+	}
+	p := covariance.rows
+	stdDevs := make([]float64, p)
+	for i := range stdDevs {
+		stdDevs[i] = math.Sqrt(covariance.data[i*p+i])
+		if stdDevs[i] == 0 {
+			return nil, fmt.Errorf("column %d has a standard deviation of 0", i)
+		}
+	}
+	correlation := NewMatrix(p, p)
+	for i := 0; i < p; i++ {
+		for j := 0; j < p; j++ {
+			correlation.data[i*p+j] = covariance.data[i*p+j] / stdDevs[i] / stdDevs[j]
+		}
+		correlation.data[i*p+i] = 1
+	}
+	return correlation, nil
+}
+
+// deMeanedColumns pulls each column out of a data matrix with GetColumn
+// and de-means it, it also returns the divisor to normalize sums of
+// products of the columns by
+func deMeanedColumns(data [][]float64, population bool) (columns [][]float64, divisor float64, err error) {
+	rows, p := Shape(data)
+	divisor = float64(rows - 1)
+	if population {
+		divisor = float64(rows)
+	}
+	if divisor < 1 {
+		return nil, 0, errors.New("the data matrix does not have enough rows")
+	}
+	for _, row := range data {
+		if len(row) != p {
+			return nil, 0, errors.New("every row of the matrix must have the same number of elements")
+		}
+	}
+	for j := 0; j < p; j++ {
+		column, err := GetColumn(data, j)
+		if err != nil {
+			return nil, 0, err
+		}
+		columns = append(columns, DeMeanVector(column))
+	}
+	return columns, divisor, nil
+}
@@ -1,6 +1,7 @@
 package mlscratchlib
 
 import (
+	"math/rand"
 	"reflect"
 	"sort"
 	"testing"
@@ -400,6 +401,17 @@ func TestCorrelation(t *testing.T) {
 		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
 	}
 
+	result, err = Correlation([]float64{3, 3, 3, 3, 3, 3, 3, 3}, vec8a) // test for a constant vector
+	expected = 0
+
+	if err == nil {
+		t.Errorf("Function accepted a vector with a standard deviation of 0")
+	}
+
+	if result != expected {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
 	result, err = Correlation(vec8a, vec8a) // test that matching vectors returns perfect pos correlation
 	expected = 1
 
@@ -494,12 +506,39 @@ func TestGetColumn(t *testing.T) {
 		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
 	}
 
-	result, err = GetColumn(matrix3a, len(matrix3a)+1)
+	result, err = GetColumn(matrix3a, len(matrix3a[0]))
 	expected = nil
 
 	if !reflect.DeepEqual(result, expected) {
 		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
 	}
+
+	// wide data with more columns than rows, up to the last column
+	wide := [][]float64{{1, 2, 3, 4, 5}, {6, 7, 8, 9, 10}}
+	result, err = GetColumn(wide, 4)
+	expected = []float64{5, 10}
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	for _, number := range []int{-1, 5} { // test the bounds on both sides
+		result, err = GetColumn(wide, number)
+
+		if err == nil || result != nil {
+			t.Errorf("Function accepted column %v of a matrix with 5 columns", number)
+		}
+	}
+
+	result, err = GetColumn([][]float64{{1, 2}, {3}}, 1) // test for a short row
+
+	if err == nil || result != nil {
+		t.Errorf("Function accepted a row without the column")
+	}
 }
 
 func TestCreateMatrix(t *testing.T) {
@@ -530,3 +569,407 @@ func TestIsDiagonal(t *testing.T) {
 		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
 	}
 }
+
+func TestCovarianceMatrix(t *testing.T) {
+	// each column of data is a variable, each row an observation
+	data := [][]float64{{1, 2}, {2, 4}, {3, 3}, {4, 7}}
+
+	result, err := CovarianceMatrix(data, false)
+	xy, _ := Covariance([]float64{1, 2, 3, 4}, []float64{2, 4, 3, 7})
+	expected := [][]float64{{VarianceVector([]float64{1, 2, 3, 4}), xy}, {xy, VarianceVector([]float64{2, 4, 3, 7})}}
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !matricesAlmostEqual(result, mustMatrix(t, expected), 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result.ToSlices())
+	}
+
+	// population normalization divides by n instead of n - 1
+	result, err = CovarianceMatrix(data, true)
+	expected = [][]float64{{1.25, 1.75}, {1.75, 3.5}}
+
+	if !matricesAlmostEqual(result, mustMatrix(t, expected), 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result.ToSlices())
+	}
+
+	// wide data with more variables than observations, the covariance of
+	// columns j and k is d_j * d_k / 2 for the row differences d
+	result, err = CovarianceMatrix([][]float64{{1, 2, 3, 4, 5}, {2, 4, 1, 0, 9}}, false)
+	differences := []float64{1, 2, -2, -4, 4}
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	} else {
+		for j := range differences {
+			for k := range differences {
+				if expected := differences[j] * differences[k] / 2; !almostEqual(result.At(j, k), expected, 1e-12) {
+					t.Errorf("(%v, %v)\nExpected result of:\n%v\ngot result:\n%v", j, k, expected, result.At(j, k))
+				}
+			}
+		}
+	}
+
+	result, err = CovarianceMatrix([][]float64{{1, 2}}, false) // test for too few rows
+
+	if err == nil || result != nil {
+		t.Errorf("Function accepted a single observation for the sample covariance")
+	}
+
+	result, err = CovarianceMatrix([][]float64{{1, 2}, {3}}, false) // test for ragged rows
+
+	if err == nil || result != nil {
+		t.Errorf("Function accepted ragged rows")
+	}
+}
+
+func TestCorrelationMatrix(t *testing.T) {
+	// columns of matrix3a transposed are vec8a, vec8b and vec8c
+	data := mustMatrix(t, matrix3a).Transpose().ToSlices()
+
+	result, err := CorrelationMatrix(data)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	vectors := [][]float64{vec8a, vec8b, vec8c}
+	for i := range vectors {
+		for j := range vectors {
+			expected, _ := Correlation(vectors[i], vectors[j])
+			if !almostEqual(result.At(i, j), expected, 1e-12) {
+				t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result.At(i, j))
+			}
+		}
+	}
+
+	result, err = CorrelationMatrix([][]float64{{1, 2}, {1, 3}, {1, 4}}) // test for a constant column
+
+	if err == nil || result != nil {
+		t.Errorf("Function accepted a column with zero standard deviation")
+	}
+}
+
+func TestAddVectorTo(t *testing.T) {
+	var err error
+	var expected []float64
+
+	dst := make([]float64, len(vec8a))
+	err = AddVectorTo(dst, vec8a, vec8b)
+	expected, _ = AddVector(vec8a, vec8b)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !reflect.DeepEqual(dst, expected) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, dst)
+	}
+
+	err = AddVectorTo(dst, vec8a, vec10a) // test the length mismatch logic
+
+	if err == nil {
+		t.Errorf("Function accepted vectors of mismatched lengths")
+	}
+
+	err = AddVectorTo(dst[:2], vec8a, vec8b) // test the destination length logic
+
+	if err == nil {
+		t.Errorf("Function accepted a destination of the wrong length")
+	}
+}
+
+func TestSubtractVectorTo(t *testing.T) {
+	var err error
+	var expected []float64
+
+	// the destination may alias an input
+	dst := copyVector(vec8a)
+	err = SubtractVectorTo(dst, dst, vec8b)
+	expected, _ = SubtractVector(vec8a, vec8b)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !reflect.DeepEqual(dst, expected) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, dst)
+	}
+
+	err = SubtractVectorTo(dst, vec8b, vec10a) // test the length mismatch logic
+
+	if err == nil {
+		t.Errorf("Function accepted vectors of mismatched lengths")
+	}
+}
+
+func TestScalarMultiplyTo(t *testing.T) {
+	var err error
+	var expected []float64
+
+	dst := make([]float64, len(vec8a))
+	err = ScalarMultiplyTo(dst, 5.0, vec8a)
+	expected = ScalarMultiply(5.0, vec8a)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !reflect.DeepEqual(dst, expected) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, dst)
+	}
+
+	err = ScalarMultiplyTo(dst, 5.0, vec10a) // test the length mismatch logic
+
+	if err == nil {
+		t.Errorf("Function accepted a destination of the wrong length")
+	}
+}
+
+func TestAXPY(t *testing.T) {
+	var err error
+	var expected []float64
+
+	dst := []float64{1, 2, 3, 4}
+	err = AXPY(dst, 2, []float64{10, -1, 0.5, 0})
+	expected = []float64{21, 0, 4, 4}
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !reflect.DeepEqual(dst, expected) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, dst)
+	}
+
+	err = AXPY(dst, 2, vec10a) // test the length mismatch logic
+
+	if err == nil {
+		t.Errorf("Function accepted vectors of mismatched lengths")
+	}
+}
+
+func TestDeMeanVectorTo(t *testing.T) {
+	var err error
+	var expected []float64
+
+	dst := make([]float64, len(vec8a))
+	err = DeMeanVectorTo(dst, vec8a)
+	expected = DeMeanVector(vec8a)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !reflect.DeepEqual(dst, expected) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, dst)
+	}
+
+	err = DeMeanVectorTo(dst, vec10a) // test the length mismatch logic
+
+	if err == nil {
+		t.Errorf("Function accepted a destination of the wrong length")
+	}
+}
+
+// the benchmarks below compare the allocating vector functions with their
+// destination-taking variants, run them with go test -bench . -benchmem
+
+// benchVector is long enough that append has to grow the slice several times
+var benchVector = CreateMatrix(1000, 1, func(i int, j int) float64 { return float64(j) })[0]
+
+func BenchmarkAddVector(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		AddVector(benchVector, benchVector)
+	}
+}
+
+func BenchmarkAddVectorTo(b *testing.B) {
+	dst := make([]float64, len(benchVector))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		AddVectorTo(dst, benchVector, benchVector)
+	}
+}
+
+func BenchmarkSubtractVector(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		SubtractVector(benchVector, benchVector)
+	}
+}
+
+func BenchmarkSubtractVectorTo(b *testing.B) {
+	dst := make([]float64, len(benchVector))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		SubtractVectorTo(dst, benchVector, benchVector)
+	}
+}
+
+func BenchmarkScalarMultiply(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ScalarMultiply(0.5, benchVector)
+	}
+}
+
+func BenchmarkScalarMultiplyTo(b *testing.B) {
+	dst := make([]float64, len(benchVector))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ScalarMultiplyTo(dst, 0.5, benchVector)
+	}
+}
+
+func BenchmarkAXPY(b *testing.B) {
+	dst := make([]float64, len(benchVector))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		AXPY(dst, 0.5, benchVector)
+	}
+}
+
+func BenchmarkDeMeanVector(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		DeMeanVector(benchVector)
+	}
+}
+
+func BenchmarkDeMeanVectorTo(b *testing.B) {
+	dst := make([]float64, len(benchVector))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		DeMeanVectorTo(dst, benchVector)
+	}
+}
+
+func TestStatisticsDoNotMutate(t *testing.T) {
+	vector := []float64{5, 3, 9, 1, 7, 2}
+	original := copyVector(vector)
+
+	VectorMedian(vector)
+	VectorMedianSelect(vector)
+	QuantileVector(vector, 0.5)
+	QuantileVectorSelect(vector, 0.5)
+	RangeVector(vector)
+	InterQuartileRangeVector(vector)
+
+	if !reflect.DeepEqual(vector, original) {
+		t.Errorf("Expected the vector to keep its order:\n%v\ngot:\n%v", original, vector)
+	}
+}
+
+func TestVectorMedianInPlace(t *testing.T) {
+	vector := []float64{5, 3, 9, 1, 7, 2}
+
+	result := VectorMedianInPlace(vector)
+	expected := 4.0
+
+	if result != expected {
+		t.Errorf("\nExpected: %f\nGot: %f", expected, result)
+	}
+
+	if !sort.Float64sAreSorted(vector) {
+		t.Errorf("Expected the vector to be sorted in place, got %v", vector)
+	}
+}
+
+func TestVectorMedianSelect(t *testing.T) {
+	var expected, result float64
+
+	result = VectorMedianSelect([]float64{})
+	expected = 0
+
+	if result != expected {
+		t.Errorf("\nExpected: %f\nGot: %f", expected, result)
+	}
+
+	// check against the sorting implementation on vectors with lots of ties
+	rng := rand.New(rand.NewSource(42))
+	for n := 1; n < 50; n++ {
+		vector := make([]float64, n)
+		for i := range vector {
+			vector[i] = float64(rng.Intn(10))
+		}
+		result = VectorMedianSelect(vector)
+		expected = VectorMedian(vector)
+
+		if result != expected {
+			t.Errorf("%v\nExpected: %f\nGot: %f", vector, expected, result)
+		}
+	}
+}
+
+func TestQuantileVectorInPlace(t *testing.T) {
+	vector := []float64{8, 7, 6, 5, 4, 3, 2, 1}
+
+	result, err := QuantileVectorInPlace(vector, .50)
+	expected := 5.0
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if result != expected {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	if !sort.Float64sAreSorted(vector) {
+		t.Errorf("Expected the vector to be sorted in place, got %v", vector)
+	}
+}
+
+func TestInterQuartileRangeVectorInPlace(t *testing.T) {
+	vector := []float64{9, 2, 7, 4, 1, 8, 3, 6, 5}
+	original := copyVector(vector)
+
+	result := InterQuartileRangeVectorInPlace(vector)
+	expected := InterQuartileRangeVector(original)
+
+	if result != expected {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	if !sort.Float64sAreSorted(vector) {
+		t.Errorf("Expected the vector to be sorted in place, got %v", vector)
+	}
+
+	// the vector is only reordered, it holds the same elements
+	sortedOriginal := copyVector(original)
+	sort.Float64s(sortedOriginal)
+
+	if !reflect.DeepEqual(vector, sortedOriginal) {
+		t.Errorf("Expected the elements:\n%v\ngot:\n%v", sortedOriginal, vector)
+	}
+}
+
+func TestQuantileVectorSelect(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	vector := make([]float64, 101)
+	for i := range vector {
+		vector[i] = rng.NormFloat64()
+	}
+
+	for _, percentile := range []float64{0, 0.1, 0.25, 0.5, 0.75, 0.99} {
+		result, err := QuantileVectorSelect(vector, percentile)
+		expected, _ := QuantileVector(vector, percentile)
+
+		if err != nil {
+			t.Errorf("Error: %v", err)
+		}
+
+		if result != expected {
+			t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+		}
+	}
+
+	_, err := QuantileVectorSelect(vector, 1.5) // test the percentile range logic
+
+	if err == nil {
+		t.Errorf("Function accepted a percentile greater than 1")
+	}
+}
@@ -0,0 +1,212 @@
+package mlscratchlib
+
+import (
+	"errors"
+	"math"
+)
+
+// machineEpsilon is the difference between 1 and the next largest
+// float64, it is used to decide when a pivot is small enough that
+// a matrix should be treated as singular
+var machineEpsilon = math.Nextafter(1, 2) - 1
+
+// LU holds the LU factorization of a square matrix A with partial
+// pivoting so that P*A = L*U, where P is a row permutation, L is unit
+// lower triangular and U is upper triangular
+type LU struct {
+	lu       *Matrix // L below the diagonal (its 1s are implied) and U on and above it
+	pivot    []int   // pivot[i] is the row of A that ended up in row i
+	sign     float64 // 1 or -1 depending on the number of row swaps
+	singular bool
+}
+
+// LUDecompose accepts a square matrix and returns its LU factorization
+// computed with Gaussian elimination and partial pivoting. A singular
+// matrix is factorized without error, but Solve and Inverse will
+// refuse to use it
+func LUDecompose(a *Matrix) (*LU, error) {
+	if a.rows != a.cols {
+		return nil, errors.New("the matrix must be square")
+	}
+	n := a.rows
+	lu := a.Copy()
+	pivot := make([]int, n)
+	for i := range pivot {
+		pivot[i] = i
+	}
+	sign := 1.0
+
+	// pivots smaller than this relative to the largest element of a
+	// are indistinguishable from rounding error
+	var maxElement float64
+	for _, element := range a.data {
+		maxElement = math.Max(maxElement, math.Abs(element))
+	}
+	tolerance := float64(n) * machineEpsilon * maxElement
+	singular := false
+
+	for k := 0; k < n; k++ {
+		// find the row with the largest element in column k and swap it up
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(lu.data[i*n+k]) > math.Abs(lu.data[p*n+k]) {
+				p = i
+			}
+		}
+		if p != k {
+			for j := 0; j < n; j++ {
+				lu.data[p*n+j], lu.data[k*n+j] = lu.data[k*n+j], lu.data[p*n+j]
+			}
+			pivot[p], pivot[k] = pivot[k], pivot[p]
+			sign = -sign
+		}
+
+		if math.Abs(lu.data[k*n+k]) <= tolerance {
+			singular = true
+			continue
+		}
+
+		// eliminate everything below the pivot, storing the multipliers in L
+		for i := k + 1; i < n; i++ {
+			multiplier := lu.data[i*n+k] / lu.data[k*n+k]
+			lu.data[i*n+k] = multiplier
+			for j := k + 1; j < n; j++ {
+				lu.data[i*n+j] -= multiplier * lu.data[k*n+j]
+			}
+		}
+	}
+
+	return &LU{lu: lu, pivot: pivot, sign: sign, singular: singular}, nil
+}
+
+// IsSingular reports whether the factorized matrix is singular
+func (f *LU) IsSingular() bool {
+	return f.singular
+}
+
+// L returns the unit lower triangular factor
+func (f *LU) L() *Matrix {
+	n := f.lu.rows
+	return NewMatrixFromFunction(n, n, func(i int, j int) float64 {
+		if i == j {
+			return 1
+		} else if i > j {
+			return f.lu.data[i*n+j]
+		}
+		return 0
+	})
+}
+
+// U returns the upper triangular factor
+func (f *LU) U() *Matrix {
+	n := f.lu.rows
+	return NewMatrixFromFunction(n, n, func(i int, j int) float64 {
+		if i <= j {
+			return f.lu.data[i*n+j]
+		}
+		return 0
+	})
+}
+
+// Pivot returns the row permutation, element i is the index of the
+// row of the original matrix that was moved to row i
+func (f *LU) Pivot() []int {
+	pivot := make([]int, len(f.pivot))
+	copy(pivot, f.pivot)
+	return pivot
+}
+
+// Determinant returns the determinant of the factorized matrix, which
+// is the product of the diagonal of U with the sign of the permutation
+func (f *LU) Determinant() float64 {
+	n := f.lu.rows
+	determinant := f.sign
+	for i := 0; i < n; i++ {
+		determinant *= f.lu.data[i*n+i]
+	}
+	return determinant
+}
+
+// Solve accepts a vector b and returns the vector x that solves A*x = b
+// using forward and back substitution
+func (f *LU) Solve(b []float64) ([]float64, error) {
+	n := f.lu.rows
+	if len(b) != n {
+		return nil, errors.New("the vector must have one element for every row of the matrix")
+	}
+	if f.singular {
+		return nil, errors.New("the matrix is singular")
+	}
+
+	// forward substitution with the permuted b to solve L*y = P*b
+	x := make([]float64, n)
+	for i := 0; i < n; i++ {
+		sum := b[f.pivot[i]]
+		for j := 0; j < i; j++ {
+			sum -= f.lu.data[i*n+j] * x[j]
+		}
+		x[i] = sum
+	}
+
+	// back substitution to solve U*x = y
+	for i := n - 1; i >= 0; i-- {
+		sum := x[i]
+		for j := i + 1; j < n; j++ {
+			sum -= f.lu.data[i*n+j] * x[j]
+		}
+		x[i] = sum / f.lu.data[i*n+i]
+	}
+	return x, nil
+}
+
+// Inverse returns the inverse of the factorized matrix by solving
+// for each column of the identity matrix
+func (f *LU) Inverse() (*Matrix, error) {
+	if f.singular {
+		return nil, errors.New("the matrix is singular")
+	}
+	n := f.lu.rows
+	inverse := NewMatrix(n, n)
+	unit := make([]float64, n)
+	for j := 0; j < n; j++ {
+		unit[j] = 1
+		column, err := f.Solve(unit)
+		if err != nil {
+			return nil, err
+		}
+		unit[j] = 0
+		for i, element := range column {
+			inverse.data[i*n+j] = element
+		}
+	}
+	return inverse, nil
+}
+
+// Determinant accepts a square matrix and returns its determinant
+func Determinant(a *Matrix) (float64, error) {
+	f, err := LUDecompose(a)
+	if err != nil {
+		return 0, err
+	}
+	return f.Determinant(), nil
+}
+
+// Inverse accepts a square matrix and returns its inverse, or an
+// error if the matrix is not square or is singular
+func Inverse(a *Matrix) (*Matrix, error) {
+	f, err := LUDecompose(a)
+	if err != nil {
+		return nil, err
+	}
+	return f.Inverse()
+}
+
+// Solve accepts a square matrix a and a vector b and returns the
+// vector x that solves the linear system a*x = b
+func Solve(a *Matrix, b []float64) ([]float64, error) {
+	f, err := LUDecompose(a)
+	if err != nil {
+		return nil, err
+	}
+	return f.Solve(b)
+}
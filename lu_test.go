@@ -0,0 +1,123 @@
+package mlscratchlib
+
+import (
+	"reflect"
+	"testing"
+)
+
+// sample square matrixes for testing factorizations
+var square3a = [][]float64{{2, 1, 1}, {4, -6, 0}, {-2, 7, 2}}
+var singular3a = [][]float64{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}
+
+func TestLUDecompose(t *testing.T) {
+	a := mustMatrix(t, square3a)
+
+	f, err := LUDecompose(a)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	// rebuild P*A from the pivot and check that it matches L*U
+	permuted := NewMatrix(3, 3)
+	for i, p := range f.Pivot() {
+		copy(permuted.data[i*3:(i+1)*3], a.Row(p))
+	}
+	result, _ := f.L().Mul(f.U())
+
+	if !matricesAlmostEqual(result, permuted, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", permuted.ToSlices(), result.ToSlices())
+	}
+
+	f, err = LUDecompose(mustMatrix(t, matrix2x3)) // test the non-square logic
+
+	if err == nil || f != nil {
+		t.Errorf("Function accepted a non-square matrix")
+	}
+}
+
+func TestDeterminant(t *testing.T) {
+	var err error
+	var expected, result float64
+
+	result, err = Determinant(mustMatrix(t, square3a))
+	expected = -16
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	result, err = Determinant(Identity(4))
+	expected = 1
+
+	if result != expected {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	result, err = Determinant(mustMatrix(t, singular3a))
+	expected = 0
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+}
+
+func TestInverse(t *testing.T) {
+	a := mustMatrix(t, square3a)
+
+	inverse, err := Inverse(a)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	result, _ := a.Mul(inverse)
+
+	if !matricesAlmostEqual(result, Identity(3), 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", Identity(3).ToSlices(), result.ToSlices())
+	}
+
+	inverse, err = Inverse(mustMatrix(t, singular3a)) // test the singular logic
+
+	if err == nil || inverse != nil {
+		t.Errorf("Function inverted a singular matrix, got %v", inverse)
+	}
+}
+
+func TestSolve(t *testing.T) {
+	var err error
+	var expected, result []float64
+
+	result, err = Solve(mustMatrix(t, square3a), []float64{5, -2, 9})
+	expected = []float64{1, 1, 2}
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !vectorsAlmostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	result, err = Solve(mustMatrix(t, square3a), vec8a) // test the length mismatch logic
+
+	if err == nil || result != nil {
+		t.Errorf("Function accepted a vector of the wrong length, got %v", result)
+	}
+
+	result, err = Solve(mustMatrix(t, singular3a), []float64{1, 2, 3}) // test the singular logic
+
+	if err == nil || !reflect.DeepEqual(result, []float64(nil)) {
+		t.Errorf("Function solved a singular system, got %v", result)
+	}
+
+	result, err = Solve(mustMatrix(t, matrix2x3), []float64{1, 2}) // test the non-square logic
+
+	if err == nil || result != nil {
+		t.Errorf("Function accepted a non-square matrix, got %v", result)
+	}
+}
@@ -0,0 +1,199 @@
+package mlscratchlib
+
+import "errors"
+
+// Matrix is a dense matrix of float64 values. The elements are stored
+// in a single contiguous slice in row-major order so that element (i, j)
+// lives at data[i*cols+j]
+type Matrix struct {
+	rows int
+	cols int
+	data []float64
+}
+
+// NewMatrix accepts a number of rows and columns and returns a
+// matrix of that shape with every element set to 0
+func NewMatrix(rows int, columns int) *Matrix {
+	if rows < 0 || columns < 0 {
+		panic("matrix dimensions must not be negative")
+	}
+	return &Matrix{rows: rows, cols: columns, data: make([]float64, rows*columns)}
+}
+
+// NewMatrixFromSlices accepts a matrix in the [][]float64 form used by
+// CreateMatrix and returns a copy of it as a *Matrix. Every row must
+// have the same number of elements
+func NewMatrixFromSlices(matrix [][]float64) (*Matrix, error) {
+	if len(matrix) < 1 {
+		return NewMatrix(0, 0), nil
+	}
+	columns := len(matrix[0])
+	m := NewMatrix(len(matrix), columns)
+	for i, row := range matrix {
+		if len(row) != columns {
+			return nil, errors.New("every row of the matrix must have the same number of elements")
+		}
+		copy(m.data[i*columns:(i+1)*columns], row)
+	}
+	return m, nil
+}
+
+// NewMatrixFromFunction works like CreateMatrix, it accepts the number
+// of rows and columns and an entry function that is used to set the
+// value of each cell based on its position, and returns a *Matrix
+func NewMatrixFromFunction(rows int, columns int, entryFunction func(int, int) float64) *Matrix {
+	m := NewMatrix(rows, columns)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < columns; j++ {
+			m.data[i*columns+j] = entryFunction(i, j)
+		}
+	}
+	return m
+}
+
+// Identity returns an n x n matrix with 1s on the diagonal and 0s
+// everywhere else, built with the IsDiagonal entry function
+func Identity(n int) *Matrix {
+	return NewMatrixFromFunction(n, n, IsDiagonal)
+}
+
+// Shape returns the number of rows and columns in the matrix
+func (m *Matrix) Shape() (rows int, columns int) {
+	return m.rows, m.cols
+}
+
+// At returns the element in row i and column j
+func (m *Matrix) At(i int, j int) float64 {
+	m.checkIndex(i, j)
+	return m.data[i*m.cols+j]
+}
+
+// Set sets the element in row i and column j to value
+func (m *Matrix) Set(i int, j int, value float64) {
+	m.checkIndex(i, j)
+	m.data[i*m.cols+j] = value
+}
+
+func (m *Matrix) checkIndex(i int, j int) {
+	if i < 0 || i >= m.rows || j < 0 || j >= m.cols {
+		panic("matrix index out of range")
+	}
+}
+
+// Row returns a copy of the ith row of the matrix
+func (m *Matrix) Row(i int) []float64 {
+	m.checkIndex(i, 0)
+	row := make([]float64, m.cols)
+	copy(row, m.data[i*m.cols:(i+1)*m.cols])
+	return row
+}
+
+// Column returns a copy of the jth column of the matrix
+func (m *Matrix) Column(j int) []float64 {
+	m.checkIndex(0, j)
+	column := make([]float64, m.rows)
+	for i := range column {
+		column[i] = m.data[i*m.cols+j]
+	}
+	return column
+}
+
+// Copy returns a new matrix with the same shape and elements as m
+func (m *Matrix) Copy() *Matrix {
+	c := NewMatrix(m.rows, m.cols)
+	copy(c.data, m.data)
+	return c
+}
+
+// ToSlices returns a copy of the matrix in the [][]float64 form used
+// by CreateMatrix, GetRow and GetColumn
+func (m *Matrix) ToSlices() [][]float64 {
+	matrix := make([][]float64, m.rows)
+	for i := range matrix {
+		matrix[i] = m.Row(i)
+	}
+	return matrix
+}
+
+// Transpose returns a new matrix whose rows are the columns of m
+func (m *Matrix) Transpose() *Matrix {
+	t := NewMatrix(m.cols, m.rows)
+	for i := 0; i < m.rows; i++ {
+		for j := 0; j < m.cols; j++ {
+			t.data[j*m.rows+i] = m.data[i*m.cols+j]
+		}
+	}
+	return t
+}
+
+// Add returns a new matrix whose elements are the sum of each
+// element of m and b
+func (m *Matrix) Add(b *Matrix) (*Matrix, error) {
+	if m.rows != b.rows || m.cols != b.cols {
+		return nil, errors.New("the matrices must have the same shape")
+	}
+	sum := NewMatrix(m.rows, m.cols)
+	for i := range m.data {
+		sum.data[i] = m.data[i] + b.data[i]
+	}
+	return sum, nil
+}
+
+// Sub returns a new matrix whose elements are the difference
+// between each element of m and b
+func (m *Matrix) Sub(b *Matrix) (*Matrix, error) {
+	if m.rows != b.rows || m.cols != b.cols {
+		return nil, errors.New("the matrices must have the same shape")
+	}
+	difference := NewMatrix(m.rows, m.cols)
+	for i := range m.data {
+		difference.data[i] = m.data[i] - b.data[i]
+	}
+	return difference, nil
+}
+
+// Scale returns a new matrix whose elements are the product of
+// num and each element of m
+func (m *Matrix) Scale(num float64) *Matrix {
+	scaled := NewMatrix(m.rows, m.cols)
+	for i, element := range m.data {
+		scaled.data[i] = element * num
+	}
+	return scaled
+}
+
+// Mul returns the matrix product of m and b. The number of columns
+// in m must match the number of rows in b, the result has the
+// number of rows of m and the number of columns of b
+func (m *Matrix) Mul(b *Matrix) (*Matrix, error) {
+	if m.cols != b.rows {
+		return nil, errors.New("the number of columns in the first matrix must match the number of rows in the second")
+	}
+	product := NewMatrix(m.rows, b.cols)
+	for i := 0; i < m.rows; i++ {
+		productRow := product.data[i*b.cols : (i+1)*b.cols]
+		for k := 0; k < m.cols; k++ {
+			// walk b row by row so the inner loop reads contiguous memory
+			element := m.data[i*m.cols+k]
+			bRow := b.data[k*b.cols : (k+1)*b.cols]
+			for j, bElement := range bRow {
+				productRow[j] += element * bElement
+			}
+		}
+	}
+	return product, nil
+}
+
+// MulVector returns the vector that results from multiplying the
+// matrix by the column vector v. v must have one element for every
+// column of the matrix
+func (m *Matrix) MulVector(v []float64) ([]float64, error) {
+	if m.cols != len(v) {
+		return nil, errors.New("the vector must have one element for every column of the matrix")
+	}
+	vector := make([]float64, m.rows)
+	for i := range vector {
+		vector[i], _ = DotProduct(m.data[i*m.cols:(i+1)*m.cols], v)
+	}
+	return vector, nil
+}
@@ -0,0 +1,247 @@
+package mlscratchlib
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// sample matrixes for testing the Matrix type
+var matrix2x3 = [][]float64{{1, 2, 3}, {4, 5, 6}}
+var matrix3x2 = [][]float64{{7, 8}, {9, 10}, {11, 12}}
+
+// mustMatrix converts a [][]float64 to a *Matrix and fails the test
+// if the rows are ragged
+func mustMatrix(t *testing.T, matrix [][]float64) *Matrix {
+	t.Helper()
+	m, err := NewMatrixFromSlices(matrix)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	return m
+}
+
+// almostEqual reports whether a and b are within tolerance of each other
+func almostEqual(a float64, b float64, tolerance float64) bool {
+	return math.Abs(a-b) <= tolerance
+}
+
+// vectorsAlmostEqual reports whether every element of a is within
+// tolerance of the matching element of b
+func vectorsAlmostEqual(a []float64, b []float64, tolerance float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !almostEqual(a[i], b[i], tolerance) {
+			return false
+		}
+	}
+	return true
+}
+
+// matricesAlmostEqual reports whether a and b have the same shape and
+// every element of a is within tolerance of the matching element of b
+func matricesAlmostEqual(a *Matrix, b *Matrix, tolerance float64) bool {
+	if a.rows != b.rows || a.cols != b.cols {
+		return false
+	}
+	return vectorsAlmostEqual(a.data, b.data, tolerance)
+}
+
+func TestNewMatrixFromSlices(t *testing.T) {
+	m, err := NewMatrixFromSlices(matrix3a)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	rows, columns := m.Shape()
+	eRows, eColumns := Shape(matrix3a)
+
+	if rows != eRows || columns != eColumns {
+		t.Errorf("Expected Columns: %d, Rows: %d\nGot Columns: %d, Rows: %d", eColumns, eRows, columns, rows)
+	}
+
+	if !reflect.DeepEqual(m.ToSlices(), matrix3a) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", matrix3a, m.ToSlices())
+	}
+
+	m, err = NewMatrixFromSlices([][]float64{{1, 2}, {3}}) // test ragged rows
+
+	if err == nil || m != nil {
+		t.Errorf("Function accepted ragged rows, got %v", m)
+	}
+}
+
+func TestNewMatrixFromFunction(t *testing.T) {
+	var expected, result [][]float64
+
+	result = NewMatrixFromFunction(3, 5, IsDiagonal).ToSlices()
+	expected = CreateMatrix(5, 3, IsDiagonal)
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+}
+
+func TestIdentity(t *testing.T) {
+	var expected, result [][]float64
+
+	result = Identity(3).ToSlices()
+	expected = [][]float64{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+}
+
+func TestMatrixAtSet(t *testing.T) {
+	m := mustMatrix(t, matrix2x3)
+	m.Set(1, 2, 60)
+
+	if m.At(1, 2) != 60 || m.At(0, 1) != 2 {
+		t.Errorf("Expected 60 and 2, got %v and %v", m.At(1, 2), m.At(0, 1))
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("At accepted an index out of range")
+		}
+	}()
+	m.At(0, 3)
+}
+
+func TestMatrixRowColumn(t *testing.T) {
+	m := mustMatrix(t, matrix3a)
+
+	row := m.Row(1)
+	if !reflect.DeepEqual(row, vec8b) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", vec8b, row)
+	}
+
+	column := m.Column(1)
+	expected, _ := GetColumn(matrix3a, 1)
+	if !reflect.DeepEqual(column, expected) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, column)
+	}
+
+	row[0] = 1000 // rows are copies so the matrix must not change
+	if m.At(1, 0) != 1 {
+		t.Errorf("Row returned a slice that aliases the matrix")
+	}
+}
+
+func TestMatrixTranspose(t *testing.T) {
+	var expected, result [][]float64
+
+	result = mustMatrix(t, matrix2x3).Transpose().ToSlices()
+	expected = [][]float64{{1, 4}, {2, 5}, {3, 6}}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+}
+
+func TestMatrixAdd(t *testing.T) {
+	a := mustMatrix(t, matrix2x3)
+
+	result, err := a.Add(a)
+	expected := [][]float64{{2, 4, 6}, {8, 10, 12}}
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !reflect.DeepEqual(result.ToSlices(), expected) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result.ToSlices())
+	}
+
+	result, err = a.Add(mustMatrix(t, matrix3x2)) // test the shape mismatch logic
+
+	if err == nil || result != nil {
+		t.Errorf("Function accepted matrices with different shapes, got %v", result)
+	}
+}
+
+func TestMatrixSub(t *testing.T) {
+	a := mustMatrix(t, matrix2x3)
+
+	result, err := a.Sub(a.Scale(2))
+	expected := [][]float64{{-1, -2, -3}, {-4, -5, -6}}
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !reflect.DeepEqual(result.ToSlices(), expected) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result.ToSlices())
+	}
+
+	result, err = a.Sub(mustMatrix(t, matrix3x2)) // test the shape mismatch logic
+
+	if err == nil || result != nil {
+		t.Errorf("Function accepted matrices with different shapes, got %v", result)
+	}
+}
+
+func TestMatrixScale(t *testing.T) {
+	var expected, result [][]float64
+
+	result = mustMatrix(t, matrix2x3).Scale(0.5).ToSlices()
+	expected = [][]float64{{0.5, 1, 1.5}, {2, 2.5, 3}}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+}
+
+func TestMatrixMul(t *testing.T) {
+	a := mustMatrix(t, matrix2x3)
+	b := mustMatrix(t, matrix3x2)
+
+	result, err := a.Mul(b)
+	expected := [][]float64{{58, 64}, {139, 154}}
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !reflect.DeepEqual(result.ToSlices(), expected) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result.ToSlices())
+	}
+
+	result, err = a.Mul(Identity(3)) // multiplying by the identity returns the same matrix
+
+	if !reflect.DeepEqual(result.ToSlices(), matrix2x3) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", matrix2x3, result.ToSlices())
+	}
+
+	result, err = a.Mul(a) // test the shape mismatch logic
+
+	if err == nil || result != nil {
+		t.Errorf("Function accepted matrices with mismatched shapes, got %v", result)
+	}
+}
+
+func TestMatrixMulVector(t *testing.T) {
+	var err error
+	var expected, result []float64
+
+	result, err = mustMatrix(t, matrix2x3).MulVector([]float64{1, 0, -1})
+	expected = []float64{-2, -2}
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	result, err = mustMatrix(t, matrix2x3).MulVector(vec8a) // test the length mismatch logic
+
+	if err == nil || result != nil {
+		t.Errorf("Function accepted a vector of the wrong length, got %v", result)
+	}
+}
@@ -0,0 +1,228 @@
+package mlscratchlib
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+)
+
+// MultivariateNormal is the normal distribution over vectors with a given
+// mean vector and covariance matrix. It keeps the Cholesky factorization
+// of the covariance for evaluating densities and drawing samples
+type MultivariateNormal struct {
+	mean       []float64
+	covariance *Matrix
+	cholesky   *Cholesky
+}
+
+// NewMultivariateNormal accepts a mean vector and a covariance matrix and
+// returns the multivariate normal distribution with those parameters. It
+// returns an error if the covariance matrix doesn't match the length of
+// the mean or isn't symmetric positive definite
+func NewMultivariateNormal(mean []float64, covariance *Matrix) (*MultivariateNormal, error) {
+	if len(mean) < 1 {
+		return nil, errors.New("something went wrong vector length is 0")
+	}
+	rows, columns := covariance.Shape()
+	if rows != len(mean) || columns != len(mean) {
+		return nil, errors.New("the covariance matrix must have a row and a column for every element of the mean")
+	}
+	cholesky, err := CholeskyDecompose(covariance)
+	if err != nil {
+		return nil, err
+	}
+	return &MultivariateNormal{mean: copyVector(mean), covariance: covariance.Copy(), cholesky: cholesky}, nil
+}
+
+// FitMultivariateNormal accepts a data matrix where each row is an
+// observation and each column is a variable, and returns the maximum
+// likelihood multivariate normal, whose mean is the column means and whose
+// covariance divides by the number of rows rather than the number of rows
+// minus one. It needs more rows than columns for the covariance to be
+// positive definite
+func FitMultivariateNormal(data [][]float64) (*MultivariateNormal, error) {
+	covariance, err := CovarianceMatrix(data, true)
+	if err != nil {
+		return nil, err
+	}
+	mean, err := MeanVector(data)
+	if err != nil {
+		return nil, err
+	}
+	return NewMultivariateNormal(mean, covariance)
+}
+
+// Dimension returns the length of the vectors of the distribution
+func (m *MultivariateNormal) Dimension() int {
+	return len(m.mean)
+}
+
+// Mean returns a copy of the mean vector
+func (m *MultivariateNormal) Mean() []float64 {
+	return copyVector(m.mean)
+}
+
+// Covariance returns a copy of the covariance matrix
+func (m *MultivariateNormal) Covariance() *Matrix {
+	return m.covariance.Copy()
+}
+
+// MahalanobisDistance returns the squared Mahalanobis distance of x from
+// the mean, (x - mean)^T * covariance^-1 * (x - mean). It is the number of
+// standard deviations squared that x lies from the mean once correlations
+// are accounted for, so it makes a natural anomaly score
+func (m *MultivariateNormal) MahalanobisDistance(x []float64) (float64, error) {
+	deviation, err := SubtractVector(x, m.mean)
+	if err != nil {
+		return 0, err
+	}
+	solved, err := m.cholesky.Solve(deviation)
+	if err != nil {
+		return 0, err
+	}
+	return DotProduct(deviation, solved)
+}
+
+// LogPDF returns the log of the density of the distribution at x
+func (m *MultivariateNormal) LogPDF(x []float64) (float64, error) {
+	distance, err := m.MahalanobisDistance(x)
+	if err != nil {
+		return 0, err
+	}
+	k := float64(len(m.mean))
+	return -(k*math.Log(2*math.Pi) + m.cholesky.LogDeterminant() + distance) / 2, nil
+}
+
+// PDF returns the density of the distribution at x. It underflows to 0
+// far from the mean or in high dimensions, where LogPDF is more useful
+func (m *MultivariateNormal) PDF(x []float64) (float64, error) {
+	logDensity, err := m.LogPDF(x)
+	if err != nil {
+		return 0, err
+	}
+	return math.Exp(logDensity), nil
+}
+
+// Rand returns a random vector from the distribution, mean + L * z for the
+// Cholesky factor L of the covariance and a vector z of independent
+// standard normal numbers. It draws from rng, or from the global source in
+// math/rand when rng is nil
+func (m *MultivariateNormal) Rand(rng *rand.Rand) []float64 {
+	z := make([]float64, len(m.mean))
+	for i := range z {
+		z[i] = randNormFloat64(rng)
+	}
+	// L is square with one column for every element of z, so this can't fail
+	sample, _ := m.cholesky.l.MulVector(z)
+	AXPY(sample, 1, m.mean)
+	return sample
+}
+
+// Marginal accepts the indices of some of the variables and returns their
+// joint distribution, which is the multivariate normal made of the
+// matching elements of the mean and rows and columns of the covariance
+func (m *MultivariateNormal) Marginal(indices []int) (*MultivariateNormal, error) {
+	if err := m.checkIndices(indices); err != nil {
+		return nil, err
+	}
+	return NewMultivariateNormal(subvector(m.mean, indices), submatrix(m.covariance, indices, indices))
+}
+
+// Conditional accepts the indices of some of the variables and their
+// observed values, and returns the distribution of the remaining variables,
+// in their original order, given those values. With a for the remaining
+// variables and b for the observed ones its mean is
+// mean_a + cov_ab * cov_bb^-1 * (values - mean_b) and its covariance is
+// cov_aa - cov_ab * cov_bb^-1 * cov_ba
+func (m *MultivariateNormal) Conditional(indices []int, values []float64) (*MultivariateNormal, error) {
+	if err := m.checkIndices(indices); err != nil {
+		return nil, err
+	} else if len(values) != len(indices) {
+		return nil, errors.New("there must be one value for every index")
+	} else if len(indices) == len(m.mean) {
+		return nil, errors.New("at least one variable must remain after conditioning")
+	}
+	observed := make(map[int]bool)
+	for _, i := range indices {
+		observed[i] = true
+	}
+	var remaining []int
+	for i := range m.mean {
+		if !observed[i] {
+			remaining = append(remaining, i)
+		}
+	}
+
+	observedCovariance, err := CholeskyDecompose(submatrix(m.covariance, indices, indices))
+	if err != nil {
+		return nil, err
+	}
+	deviation, err := SubtractVector(values, subvector(m.mean, indices))
+	if err != nil {
+		return nil, err
+	}
+	weights, err := observedCovariance.Solve(deviation)
+	if err != nil {
+		return nil, err
+	}
+	cross := submatrix(m.covariance, remaining, indices)
+	shift, err := cross.MulVector(weights)
+	if err != nil {
+		return nil, err
+	}
+	mean, err := AddVector(subvector(m.mean, remaining), shift)
+	if err != nil {
+		return nil, err
+	}
+
+	// subtract cov_ab * cov_bb^-1 * cov_ba one column of cov_ba at a time
+	covariance := submatrix(m.covariance, remaining, remaining)
+	for j := range remaining {
+		solved, err := observedCovariance.Solve(cross.Row(j))
+		if err != nil {
+			return nil, err
+		}
+		reduction, err := cross.MulVector(solved)
+		if err != nil {
+			return nil, err
+		}
+		for i := range remaining {
+			covariance.Set(i, j, covariance.At(i, j)-reduction[i])
+		}
+	}
+	return NewMultivariateNormal(mean, covariance)
+}
+
+// checkIndices returns an error unless indices is a non-empty list of
+// distinct variables of the distribution
+func (m *MultivariateNormal) checkIndices(indices []int) error {
+	if len(indices) < 1 {
+		return errors.New("at least one index is needed")
+	}
+	seen := make(map[int]bool)
+	for _, i := range indices {
+		if i < 0 || i >= len(m.mean) {
+			return errors.New("index out of range")
+		} else if seen[i] {
+			return errors.New("indices must not repeat")
+		}
+		seen[i] = true
+	}
+	return nil
+}
+
+// subvector returns the elements of vector at the given indices
+func subvector(vector []float64, indices []int) []float64 {
+	result := make([]float64, len(indices))
+	for k, i := range indices {
+		result[k] = vector[i]
+	}
+	return result
+}
+
+// submatrix returns the matrix made of the given rows and columns of m
+func submatrix(m *Matrix, rows []int, columns []int) *Matrix {
+	return NewMatrixFromFunction(len(rows), len(columns), func(i int, j int) float64 {
+		return m.At(rows[i], columns[j])
+	})
+}
@@ -0,0 +1,151 @@
+package mlscratchlib
+
+import (
+	"math"
+	"testing"
+)
+
+var mvnMean3a = []float64{1, -2, 0.5}
+var mvnCovariance3a = [][]float64{{4, 1, 0.5}, {1, 3, 0.2}, {0.5, 0.2, 2}}
+
+func mustMultivariateNormal(t *testing.T, mean []float64, covariance [][]float64) *MultivariateNormal {
+	t.Helper()
+	m, err := NewMultivariateNormal(mean, mustMatrix(t, covariance))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	return m
+}
+
+func TestMultivariateNormalLogPDF(t *testing.T) {
+	// one dimension is the normal distribution
+	m := mustMultivariateNormal(t, []float64{3}, [][]float64{{4}})
+	result, err := m.LogPDF([]float64{4.5})
+	expected := Normal{Mu: 3, Sigma: 2}.LogPDF(4.5)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	// independent variables multiply their densities
+	m = mustMultivariateNormal(t, []float64{0, 1}, [][]float64{{1, 0}, {0, 9}})
+	result, _ = m.PDF([]float64{0.5, -2})
+	expected = NormalProbabilityDistribution(0.5, 0, 1) * NormalProbabilityDistribution(-2, 1, 3)
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	// the squared Mahalanobis distance of a point one standard deviation out
+	result, _ = m.MahalanobisDistance([]float64{0, 4})
+	expected = 1
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	_, err = m.LogPDF([]float64{1, 2, 3}) // test the length mismatch logic
+
+	if err == nil {
+		t.Errorf("Function accepted a vector of the wrong length")
+	}
+
+	_, err = NewMultivariateNormal([]float64{0, 0}, mustMatrix(t, [][]float64{{1, 2}, {2, 1}})) // not positive definite
+
+	if err == nil {
+		t.Errorf("Function accepted a covariance matrix that is not positive definite")
+	}
+}
+
+func TestMultivariateNormalMarginalConditional(t *testing.T) {
+	// the bivariate case worked by hand
+	m := mustMultivariateNormal(t, []float64{1, 2}, [][]float64{{4, 2}, {2, 3}})
+	conditional, err := m.Conditional([]int{0}, []float64{3})
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !vectorsAlmostEqual(conditional.Mean(), []float64{3}, 1e-12) || !almostEqual(conditional.Covariance().At(0, 0), 2, 1e-12) {
+		t.Errorf("Expected a mean of 3 and a variance of 2, got %v and %v", conditional.Mean(), conditional.Covariance().At(0, 0))
+	}
+
+	// the joint density is the marginal density times the conditional density
+	m = mustMultivariateNormal(t, mvnMean3a, mvnCovariance3a)
+	x := []float64{0.3, -1, 2}
+	joint, _ := m.LogPDF(x)
+
+	marginal, err := m.Marginal([]int{2, 0})
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	conditional, err = m.Conditional([]int{2, 0}, []float64{x[2], x[0]})
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	marginalDensity, _ := marginal.LogPDF([]float64{x[2], x[0]})
+	conditionalDensity, _ := conditional.LogPDF([]float64{x[1]})
+
+	if !almostEqual(marginalDensity+conditionalDensity, joint, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", joint, marginalDensity+conditionalDensity)
+	}
+
+	if result := marginal.Covariance().At(0, 1); result != 0.5 {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", 0.5, result)
+	}
+
+	_, err = m.Marginal([]int{0, 0}) // test for repeated indices
+
+	if err == nil {
+		t.Errorf("Function accepted repeated indices")
+	}
+
+	_, err = m.Conditional([]int{0, 1, 2}, []float64{1, 2, 3}) // nothing left to condition
+
+	if err == nil {
+		t.Errorf("Function accepted conditioning on every variable")
+	}
+}
+
+func TestMultivariateNormalRandFit(t *testing.T) {
+	m := mustMultivariateNormal(t, mvnMean3a, mvnCovariance3a)
+	rng := NewRand(1)
+	data := make([][]float64, 50000)
+	for i := range data {
+		data[i] = m.Rand(rng)
+	}
+
+	fitted, err := FitMultivariateNormal(data)
+
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	if !vectorsAlmostEqual(fitted.Mean(), mvnMean3a, 0.05) {
+		t.Errorf("Expected result near:\n%v\ngot result:\n%v", mvnMean3a, fitted.Mean())
+	}
+
+	expected := mustMatrix(t, mvnCovariance3a)
+	if !matricesAlmostEqual(fitted.Covariance(), expected, 0.1) {
+		t.Errorf("Expected result near:\n%v\ngot result:\n%v", mvnCovariance3a, fitted.Covariance().ToSlices())
+	}
+
+	// the maximum likelihood covariance divides by the number of rows
+	small := [][]float64{{1, 2}, {3, 1}, {2, 6}, {0, 3}}
+	fitted, _ = FitMultivariateNormal(small)
+	population, _ := CovarianceMatrix(small, true)
+
+	if !matricesAlmostEqual(fitted.Covariance(), population, 1e-12) || math.Abs(fitted.Mean()[1]-3) > 1e-12 {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", population.ToSlices(), fitted.Covariance().ToSlices())
+	}
+
+	_, err = FitMultivariateNormal([][]float64{{1, 2}, {2, 4}}) // too few rows for a positive definite covariance
+
+	if err == nil {
+		t.Errorf("Function accepted too few rows")
+	}
+}
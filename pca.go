@@ -0,0 +1,327 @@
+package mlscratchlib
+
+import (
+	"errors"
+	"math"
+)
+
+// PCAMethod selects how FitPCA finds the principal components
+type PCAMethod int
+
+const (
+	// PCAEigen takes the top eigenvectors of the covariance matrix,
+	// this is exact and is the best choice for most data
+	PCAEigen PCAMethod = iota
+	// PCAGradientAscent finds one direction at a time by climbing the
+	// directional variance with gradient ascent, then removes that
+	// direction from the data before finding the next one
+	PCAGradientAscent
+)
+
+// PCA holds a fitted principal component analysis
+type PCA struct {
+	means             []float64
+	components        [][]float64 // each row is a unit length principal direction
+	explainedVariance []float64
+	totalVariance     float64
+}
+
+// DeMeanMatrix accepts a data matrix where each row is an observation and
+// each column is a variable, and returns a copy where every column has
+// been de-meaned like DeMeanVector, along with the mean of each column
+func DeMeanMatrix(data [][]float64) (result [][]float64, means []float64, err error) {
+	rows, columns := Shape(data)
+	if rows < 1 {
+		return nil, nil, errors.New("something went wrong, the data matrix has 0 rows")
+	}
+	for _, row := range data {
+		if len(row) != columns {
+			return nil, nil, errors.New("every row of the matrix must have the same number of elements")
+		}
+	}
+	means = make([]float64, columns)
+	for j := range means {
+		column, err := GetColumn(data, j)
+		if err != nil {
+			return nil, nil, err
+		}
+		means[j] = VectorMean(column)
+	}
+	for _, row := range data {
+		deMeaned, err := SubtractVector(row, means)
+		if err != nil {
+			return nil, nil, err
+		}
+		result = append(result, deMeaned)
+	}
+	return result, means, nil
+}
+
+// FitPCA accepts a data matrix where each row is an observation and each
+// column is a variable, the number of components to keep and the method
+// to find them with, and returns the fitted PCA. Each component's sign
+// is chosen so that its largest element is positive, which makes the
+// result the same whichever method is used
+func FitPCA(data [][]float64, components int, method PCAMethod) (*PCA, error) {
+	deMeaned, means, err := DeMeanMatrix(data)
+	if err != nil {
+		return nil, err
+	}
+	rows, p := Shape(deMeaned)
+	if rows < 2 {
+		return nil, errors.New("the data matrix must have at least 2 rows")
+	}
+	if components < 1 || components > p {
+		return nil, errors.New("the number of components must be between 1 and the number of columns")
+	}
+
+	// the data is already de-meaned, so the sample covariance matrix is
+	// X^T X / (n - 1) without going through CovarianceMatrix
+	x, err := NewMatrixFromSlices(deMeaned)
+	if err != nil {
+		return nil, err
+	}
+	covariance, err := x.Transpose().Mul(x)
+	if err != nil {
+		return nil, err
+	}
+	covariance = covariance.Scale(1 / float64(rows-1))
+	var totalVariance float64
+	for i := 0; i < p; i++ {
+		totalVariance += covariance.At(i, i)
+	}
+
+	pca := &PCA{means: means, totalVariance: totalVariance}
+	switch method {
+	case PCAEigen:
+		values, vectors, err := EigenSymmetric(covariance)
+		if err != nil {
+			return nil, err
+		}
+		for k := 0; k < components; k++ {
+			pca.components = append(pca.components, vectors.Column(k))
+			pca.explainedVariance = append(pca.explainedVariance, math.Max(values[k], 0))
+		}
+	case PCAGradientAscent:
+		for k := 0; k < components; k++ {
+			component := firstPrincipalComponent(deMeaned, pca.components)
+			pca.components = append(pca.components, component)
+			pca.explainedVariance = append(pca.explainedVariance, directionalVariance(deMeaned, component)/float64(rows-1))
+			deMeaned = removeProjection(deMeaned, component)
+		}
+	default:
+		return nil, errors.New("unknown PCA method")
+	}
+
+	for _, component := range pca.components {
+		orientComponent(component)
+	}
+	return pca, nil
+}
+
+// Components returns the principal directions, one unit length vector
+// per component, ordered from the most variance explained to the least
+func (pca *PCA) Components() [][]float64 {
+	var components [][]float64
+	for _, component := range pca.components {
+		components = append(components, copyVector(component))
+	}
+	return components
+}
+
+// ExplainedVariance returns the variance of the data along each component
+func (pca *PCA) ExplainedVariance() []float64 {
+	return copyVector(pca.explainedVariance)
+}
+
+// ExplainedVarianceRatio returns the fraction of the total variance of
+// the data that lies along each component
+func (pca *PCA) ExplainedVarianceRatio() []float64 {
+	if pca.totalVariance == 0 {
+		return make([]float64, len(pca.explainedVariance))
+	}
+	return ScalarMultiply(1/pca.totalVariance, pca.explainedVariance)
+}
+
+// Transform accepts a data matrix with the same columns as the data the
+// PCA was fit on and returns the coordinates of each row along each
+// principal component
+func (pca *PCA) Transform(data [][]float64) ([][]float64, error) {
+	var result [][]float64
+	for _, row := range data {
+		deMeaned, err := SubtractVector(row, pca.means)
+		if err != nil {
+			return nil, errors.New("every row must have one element for every column the PCA was fit on")
+		}
+		scores := make([]float64, len(pca.components))
+		for k, component := range pca.components {
+			scores[k], _ = DotProduct(deMeaned, component)
+		}
+		result = append(result, scores)
+	}
+	return result, nil
+}
+
+// InverseTransform accepts the output of Transform and maps each row back
+// into the original space. Any variance along the components that were
+// not kept is lost
+func (pca *PCA) InverseTransform(scores [][]float64) ([][]float64, error) {
+	var result [][]float64
+	for _, row := range scores {
+		if len(row) != len(pca.components) {
+			return nil, errors.New("every row must have one element for every component")
+		}
+		original := copyVector(pca.means)
+		for k, component := range pca.components {
+			for j, element := range component {
+				original[j] += row[k] * element
+			}
+		}
+		result = append(result, original)
+	}
+	return result, nil
+}
+
+// direction scales w to a unit vector
+func direction(w []float64) []float64 {
+	magnitude, _ := Magnitude(w)
+	return ScalarMultiply(1/magnitude, w)
+}
+
+// directionalVariance returns the sum of the squared lengths of the
+// projections of each row of the de-meaned data onto w
+func directionalVariance(data [][]float64, w []float64) float64 {
+	dir := direction(w)
+	var variance float64
+	for _, row := range data {
+		projection, _ := DotProduct(row, dir)
+		variance += projection * projection
+	}
+	return variance
+}
+
+// directionalVarianceGradient returns the gradient of directionalVariance
+// with respect to w
+func directionalVarianceGradient(data [][]float64, w []float64) []float64 {
+	dir := direction(w)
+	gradient := make([]float64, len(w))
+	for _, row := range data {
+		projection, _ := DotProduct(row, dir)
+		for j, element := range row {
+			gradient[j] += 2 * projection * element
+		}
+	}
+	return gradient
+}
+
+// firstPrincipalComponent uses gradient ascent to find the unit vector
+// that maximizes the directional variance of the de-meaned data. Each
+// step tries a range of step sizes and keeps whichever climbs the most.
+// previous holds the components already removed from the data, and the
+// result is kept orthogonal to them
+func firstPrincipalComponent(data [][]float64, previous [][]float64) []float64 {
+	stepSizes := []float64{100, 10, 1, 0.1, 0.01, 0.001, 0.0001, 0.00001}
+	const tolerance = 1e-12
+	const maxIterations = 10000
+
+	// start from the longest row, which has a positive projection onto
+	// whatever variance is left in the data. A fixed start such as the all
+	// ones vector can be orthogonal to every remaining row
+	var w []float64
+	var longest float64
+	for _, row := range data {
+		if magnitude, _ := Magnitude(row); magnitude > longest {
+			w, longest = row, magnitude
+		}
+	}
+	if longest == 0 {
+		// no variance is left, so every direction orthogonal to the
+		// previous components explains none of it
+		return orthogonalDirection(len(data[0]), previous)
+	}
+
+	value := directionalVariance(data, w)
+	for i := 0; i < maxIterations; i++ {
+		gradient := directionalVarianceGradient(data, w)
+		if magnitude, _ := Magnitude(gradient); magnitude == 0 {
+			break
+		}
+		next, nextValue := w, value
+		for _, step := range stepSizes {
+			candidate, _ := AddVector(direction(w), ScalarMultiply(step, gradient))
+			if candidateValue := directionalVariance(data, candidate); candidateValue > nextValue {
+				next, nextValue = candidate, candidateValue
+			}
+		}
+		improvement := nextValue - value
+		w, value = next, nextValue
+		if improvement <= tolerance*math.Max(value, 1) {
+			break
+		}
+	}
+
+	// rounding in removeProjection can leave the data with a trace of the
+	// previous components, so take it back out of the result
+	w = removeComponents(direction(w), previous)
+	if magnitude, _ := Magnitude(w); magnitude < 1e-8 {
+		return orthogonalDirection(len(w), previous)
+	}
+	return direction(w)
+}
+
+// removeComponents returns a copy of w with its projection onto each of
+// the unit length components subtracted out
+func removeComponents(w []float64, components [][]float64) []float64 {
+	result := copyVector(w)
+	for _, component := range components {
+		projection, _ := DotProduct(result, component)
+		result, _ = SubtractVector(result, ScalarMultiply(projection, component))
+	}
+	return result
+}
+
+// orthogonalDirection returns a unit vector of length n that is
+// orthogonal to each of the unit length components, built from whichever
+// standard basis vector has the most left after removing them
+func orthogonalDirection(n int, components [][]float64) []float64 {
+	var best []float64
+	var bestMagnitude float64
+	for j := 0; j < n; j++ {
+		basis := make([]float64, n)
+		basis[j] = 1
+		remainder := removeComponents(basis, components)
+		if magnitude, _ := Magnitude(remainder); magnitude > bestMagnitude {
+			best, bestMagnitude = remainder, magnitude
+		}
+	}
+	return direction(best)
+}
+
+// removeProjection returns a copy of the data with the component of every
+// row that lies along w subtracted out
+func removeProjection(data [][]float64, w []float64) [][]float64 {
+	dir := direction(w)
+	var result [][]float64
+	for _, row := range data {
+		projection, _ := DotProduct(row, dir)
+		remainder, _ := SubtractVector(row, ScalarMultiply(projection, dir))
+		result = append(result, remainder)
+	}
+	return result
+}
+
+// orientComponent flips the sign of the vector in place if needed so
+// that its element with the largest magnitude is positive
+func orientComponent(component []float64) {
+	largest := 0
+	for j, element := range component {
+		if math.Abs(element) > math.Abs(component[largest]) {
+			largest = j
+		}
+	}
+	if component[largest] < 0 {
+		for j := range component {
+			component[j] = -component[j]
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package mlscratchlib
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// sample data for testing PCA, the columns are strongly correlated so
+// most of the variance lies along a single direction
+var pcaData = [][]float64{
+	{2.5, 2.4, 0.5}, {0.5, 0.7, 1.1}, {2.2, 2.9, 0.9}, {1.9, 2.2, 1.4}, {3.1, 3.0, 0.2},
+	{2.3, 2.7, 0.8}, {2.0, 1.6, 1.3}, {1.0, 1.1, 0.6}, {1.5, 1.6, 1.2}, {1.1, 0.9, 0.7},
+}
+
+func TestDeMeanMatrix(t *testing.T) {
+	result, means, err := DeMeanMatrix([][]float64{{1, 10}, {3, 20}})
+	expected := [][]float64{{-1, -5}, {1, 5}}
+	expectedMeans := []float64{2, 15}
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !reflect.DeepEqual(result, expected) || !reflect.DeepEqual(means, expectedMeans) {
+		t.Errorf("Expected result of:\n%v %v\ngot result:\n%v %v", expected, expectedMeans, result, means)
+	}
+
+	result, means, err = DeMeanMatrix([][]float64{{1, 2}, {3}}) // test for ragged rows
+
+	if err == nil || result != nil {
+		t.Errorf("Function accepted ragged rows")
+	}
+}
+
+func TestFitPCA(t *testing.T) {
+	eigen, err := FitPCA(pcaData, 2, PCAEigen)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	ascent, err := FitPCA(pcaData, 2, PCAGradientAscent)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	// both methods should find the same directions and variances
+	for k := range eigen.Components() {
+		if !vectorsAlmostEqual(eigen.Components()[k], ascent.Components()[k], 1e-5) {
+			t.Errorf("Expected result of:\n%v\ngot result:\n%v", eigen.Components()[k], ascent.Components()[k])
+		}
+	}
+
+	if !vectorsAlmostEqual(eigen.ExplainedVariance(), ascent.ExplainedVariance(), 1e-8) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", eigen.ExplainedVariance(), ascent.ExplainedVariance())
+	}
+
+	// the components are the eigenvectors of the covariance matrix
+	covariance, _ := CovarianceMatrix(pcaData, false)
+	for k, component := range eigen.Components() {
+		result, _ := covariance.MulVector(component)
+		expected := ScalarMultiply(eigen.ExplainedVariance()[k], component)
+
+		if !vectorsAlmostEqual(result, expected, 1e-10) {
+			t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+		}
+	}
+
+	ratio := eigen.ExplainedVarianceRatio()
+	if ratio[0] < 0.8 || ratio[0]+ratio[1] > 1 || ratio[1] > ratio[0] {
+		t.Errorf("Unexpected explained variance ratio: %v", ratio)
+	}
+
+	// keeping every component explains all of the variance
+	full, _ := FitPCA(pcaData, 3, PCAEigen)
+	if !almostEqual(SumValues(full.ExplainedVarianceRatio()), 1, 1e-12) {
+		t.Errorf("Expected ratios to sum to 1, got %v", full.ExplainedVarianceRatio())
+	}
+
+	// wide data with more variables than observations has one component,
+	// along the difference of the two rows
+	wide := [][]float64{{1, 2, 3, 4}, {2, 5, 1, 0}}
+	expectedComponent := ScalarMultiply(1/math.Sqrt(30), []float64{-1, -3, 2, 4})
+	for _, method := range []PCAMethod{PCAEigen, PCAGradientAscent} {
+		pca, err := FitPCA(wide, 1, method)
+
+		if err != nil {
+			t.Errorf("Error: %v", err)
+			continue
+		}
+
+		if !vectorsAlmostEqual(pca.Components()[0], expectedComponent, 1e-6) || !almostEqual(pca.ExplainedVariance()[0], 15, 1e-8) {
+			t.Errorf("Expected result of:\n%v %v\ngot result:\n%v %v", expectedComponent, 15, pca.Components()[0], pca.ExplainedVariance()[0])
+		}
+	}
+
+	// after the first component is removed every remaining row is
+	// orthogonal to the all ones vector, so gradient ascent can't start there
+	diagonal := [][]float64{{1, 1}, {-1, -1}, {2, 2}, {-2, -2}, {0.5, -0.5}, {-0.5, 0.5}}
+	expectedComponents := [][]float64{{math.Sqrt2 / 2, math.Sqrt2 / 2}, {math.Sqrt2 / 2, -math.Sqrt2 / 2}}
+	expectedVariances := []float64{4, 0.2}
+	for _, method := range []PCAMethod{PCAEigen, PCAGradientAscent} {
+		pca, _ := FitPCA(diagonal, 2, method)
+
+		for k, component := range pca.Components() {
+			if !vectorsAlmostEqual(component, expectedComponents[k], 1e-6) {
+				t.Errorf("Expected result of:\n%v\ngot result:\n%v", expectedComponents[k], component)
+			}
+		}
+
+		if !vectorsAlmostEqual(pca.ExplainedVariance(), expectedVariances, 1e-8) {
+			t.Errorf("Expected result of:\n%v\ngot result:\n%v", expectedVariances, pca.ExplainedVariance())
+		}
+	}
+
+	// a second component of wide data with one direction of variance
+	// explains none of it, but is still orthogonal to the first
+	ascent, _ = FitPCA(wide, 2, PCAGradientAscent)
+	components := ascent.Components()
+	product, _ := DotProduct(components[0], components[1])
+	magnitude, _ := Magnitude(components[1])
+
+	if !almostEqual(product, 0, 1e-10) || !almostEqual(magnitude, 1, 1e-10) || !almostEqual(ascent.ExplainedVariance()[1], 0, 1e-10) {
+		t.Errorf("Expected a unit second component orthogonal to the first with no variance, got %v %v", components, ascent.ExplainedVariance())
+	}
+
+	result, err := FitPCA(pcaData, 4, PCAEigen) // test for too many components
+
+	if err == nil || result != nil {
+		t.Errorf("Function accepted more components than columns")
+	}
+}
+
+func TestPCATransform(t *testing.T) {
+	pca, _ := FitPCA(pcaData, 3, PCAEigen)
+
+	scores, err := pca.Transform(pcaData)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	// the scores are uncorrelated and their variance is the explained variance
+	covariance, _ := CovarianceMatrix(scores, false)
+	expected := NewMatrix(3, 3)
+	for k, variance := range pca.ExplainedVariance() {
+		expected.Set(k, k, variance)
+	}
+
+	if !matricesAlmostEqual(covariance, expected, 1e-10) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected.ToSlices(), covariance.ToSlices())
+	}
+
+	// with every component kept the inverse transform is lossless
+	result, err := pca.InverseTransform(scores)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !matricesAlmostEqual(mustMatrix(t, result), mustMatrix(t, pcaData), 1e-10) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", pcaData, result)
+	}
+
+	scores, err = pca.Transform([][]float64{{1, 2}}) // test the length mismatch logic
+
+	if err == nil || scores != nil {
+		t.Errorf("Function accepted a row of the wrong length")
+	}
+
+	result, err = pca.InverseTransform([][]float64{{1, 2}}) // test the length mismatch logic
+
+	if err == nil || result != nil {
+		t.Errorf("Function accepted a row of the wrong length")
+	}
+}
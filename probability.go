@@ -35,7 +35,7 @@ func UniformCumulativeDensity(number float64) float64 {
 // the center of the curve
 func NormalProbabilityDistribution(number float64, mean float64, sigma float64) float64 {
 	base := (1 / (sigma * math.Sqrt((math.Pi * 2))))
-	exponent := -(math.Pow(-(number-mean), 2) / 2 * math.Pow(sigma, 2))
+	exponent := -(math.Pow(number-mean, 2) / (2 * math.Pow(sigma, 2)))
 	return base * math.Exp(exponent)
 }
 
@@ -73,3 +73,231 @@ func InverseNormalCDF(probability, mean, sigma, tolerance float64) (mid float64)
 	}
 	return mid
 }
+
+// StudentTPDF accepts a number and the degrees of freedom of a Student's
+// t distribution and returns its probability density at that number.
+// Infinite degrees of freedom is the standard normal distribution, and
+// degrees of freedom that aren't greater than 0 return NaN
+func StudentTPDF(number float64, df float64) float64 {
+	if !(df > 0) {
+		return math.NaN()
+	} else if math.IsInf(df, 1) {
+		return NormalProbabilityDistribution(number, 0, 1)
+	}
+	lgHalfDfPlusOne, _ := math.Lgamma((df + 1) / 2)
+	lgHalfDf, _ := math.Lgamma(df / 2)
+	return math.Exp(lgHalfDfPlusOne - lgHalfDf - math.Log(df*math.Pi)/2 - (df+1)/2*math.Log1p(number*number/df))
+}
+
+// StudentTCDF accepts a number and the degrees of freedom of a Student's
+// t distribution and returns the probability that a random number is less
+// than or equal to that number
+func StudentTCDF(number float64, df float64) float64 {
+	if !(df > 0) {
+		return math.NaN()
+	} else if math.IsInf(df, 1) {
+		return NormalCDF(number, 0, 1)
+	}
+	// for small numbers, df/(df+t*t) rounds to 1, so use the symmetry of
+	// the incomplete beta to work with t*t/(df+t*t) directly
+	var tail float64
+	if number*number < df {
+		tail = (1 - regularizedIncompleteBeta(0.5, df/2, number*number/(df+number*number))) / 2
+	} else {
+		tail = regularizedIncompleteBeta(df/2, 0.5, df/(df+number*number)) / 2
+	}
+	if number > 0 {
+		return 1 - tail
+	}
+	return tail
+}
+
+// InverseStudentTCDF finds the number whose StudentTCDF is the given
+// probability to within the tolerance. Like InverseNormalCDF it searches
+// by bisection, but it first widens the search range because the tails of
+// the t distribution are much heavier than the normal's. A tolerance of 0
+// searches to the precision of a float64
+func InverseStudentTCDF(probability float64, df float64, tolerance float64) float64 {
+	if !(df > 0) || math.IsNaN(probability) {
+		return math.NaN()
+	} else if probability <= 0 {
+		return math.Inf(-1)
+	} else if probability >= 1 {
+		return math.Inf(1)
+	}
+	cdf := func(x float64) float64 { return StudentTCDF(x, df) }
+	low, hi := -10.0, 10.0
+	for cdf(low) > probability {
+		low *= 2
+	}
+	for cdf(hi) < probability {
+		hi *= 2
+	}
+	return bisect(cdf, probability, low, hi, tolerance)
+}
+
+// GammaPDF accepts a number and the shape and scale of a gamma
+// distribution and returns its probability density at that number. The
+// density is 0 for negative numbers, and shapes or scales that aren't
+// greater than 0 return NaN
+func GammaPDF(number float64, shape float64, scale float64) float64 {
+	if !(shape > 0 && scale > 0) {
+		return math.NaN()
+	} else if number < 0 {
+		return 0
+	} else if number == 0 {
+		return densityAtBoundary(shape, 1/scale)
+	}
+	lgShape, _ := math.Lgamma(shape)
+	x := number / scale
+	return math.Exp((shape-1)*math.Log(x)-x-lgShape) / scale
+}
+
+// GammaCDF accepts a number and the shape and scale of a gamma
+// distribution and returns the probability that a random number is less
+// than or equal to that number
+func GammaCDF(number float64, shape float64, scale float64) float64 {
+	if !(shape > 0 && scale > 0) {
+		return math.NaN()
+	}
+	return regularizedLowerIncompleteGamma(shape, number/scale)
+}
+
+// InverseGammaCDF finds the number whose GammaCDF is the given probability
+// to within the tolerance, searching by bisection
+func InverseGammaCDF(probability float64, shape float64, scale float64, tolerance float64) float64 {
+	if !(shape > 0 && scale > 0) {
+		return math.NaN()
+	}
+	return inversePositiveCDF(func(x float64) float64 { return GammaCDF(x, shape, scale) }, probability, tolerance)
+}
+
+// ChiSquarePDF accepts a number and the degrees of freedom of a chi-square
+// distribution and returns its probability density at that number. The
+// chi-square distribution is a gamma distribution with shape df/2 and
+// scale 2
+func ChiSquarePDF(number float64, df float64) float64 {
+	return GammaPDF(number, df/2, 2)
+}
+
+// ChiSquareCDF accepts a number and the degrees of freedom of a chi-square
+// distribution and returns the probability that a random number is less
+// than or equal to that number
+func ChiSquareCDF(number float64, df float64) float64 {
+	return GammaCDF(number, df/2, 2)
+}
+
+// InverseChiSquareCDF finds the number whose ChiSquareCDF is the given
+// probability to within the tolerance, such as the critical value of a
+// chi-square test
+func InverseChiSquareCDF(probability float64, df float64, tolerance float64) float64 {
+	return InverseGammaCDF(probability, df/2, 2, tolerance)
+}
+
+// FPDF accepts a number and the numerator and denominator degrees of
+// freedom of an F distribution and returns its probability density at that
+// number. The density is 0 for negative numbers, and degrees of freedom
+// that aren't greater than 0 return NaN
+func FPDF(number float64, df1 float64, df2 float64) float64 {
+	if !(df1 > 0 && df2 > 0) {
+		return math.NaN()
+	} else if number < 0 {
+		return 0
+	} else if number == 0 {
+		return densityAtBoundary(df1/2, 1)
+	}
+	logNumerator := df1*math.Log(df1*number) + df2*math.Log(df2) - (df1+df2)*math.Log(df1*number+df2)
+	return math.Exp(logNumerator/2 - math.Log(number) - logBeta(df1/2, df2/2))
+}
+
+// FCDF accepts a number and the numerator and denominator degrees of
+// freedom of an F distribution and returns the probability that a random
+// number is less than or equal to that number
+func FCDF(number float64, df1 float64, df2 float64) float64 {
+	if !(df1 > 0 && df2 > 0) {
+		return math.NaN()
+	} else if number <= 0 {
+		return 0
+	}
+	return regularizedIncompleteBeta(df1/2, df2/2, df1*number/(df1*number+df2))
+}
+
+// InverseFCDF finds the number whose FCDF is the given probability to
+// within the tolerance, such as the critical value of an F test
+func InverseFCDF(probability float64, df1 float64, df2 float64, tolerance float64) float64 {
+	if !(df1 > 0 && df2 > 0) {
+		return math.NaN()
+	}
+	return inversePositiveCDF(func(x float64) float64 { return FCDF(x, df1, df2) }, probability, tolerance)
+}
+
+// BetaPDF accepts a number and the shape parameters alpha and beta of a
+// beta distribution and returns its probability density at that number.
+// The density is 0 outside of [0, 1], and shapes that aren't greater than
+// 0 return NaN
+func BetaPDF(number float64, alpha float64, beta float64) float64 {
+	if !(alpha > 0 && beta > 0) {
+		return math.NaN()
+	} else if number < 0 || number > 1 {
+		return 0
+	} else if number == 0 {
+		return densityAtBoundary(alpha, beta)
+	} else if number == 1 {
+		return densityAtBoundary(beta, alpha)
+	}
+	return math.Exp((alpha-1)*math.Log(number) + (beta-1)*math.Log1p(-number) - logBeta(alpha, beta))
+}
+
+// BetaCDF accepts a number and the shape parameters alpha and beta of a
+// beta distribution and returns the probability that a random number is
+// less than or equal to that number
+func BetaCDF(number float64, alpha float64, beta float64) float64 {
+	if !(alpha > 0 && beta > 0) {
+		return math.NaN()
+	}
+	return regularizedIncompleteBeta(alpha, beta, number)
+}
+
+// InverseBetaCDF finds the number whose BetaCDF is the given probability
+// to within the tolerance, searching by bisection over [0, 1]
+func InverseBetaCDF(probability float64, alpha float64, beta float64, tolerance float64) float64 {
+	if !(alpha > 0 && beta > 0) || math.IsNaN(probability) {
+		return math.NaN()
+	} else if probability <= 0 {
+		return 0
+	} else if probability >= 1 {
+		return 1
+	}
+	return bisect(func(x float64) float64 { return BetaCDF(x, alpha, beta) }, probability, 0, 1, tolerance)
+}
+
+// densityAtBoundary returns the density at the end of the support of a
+// distribution whose density behaves like x^(shape-1) near it, which is
+// infinite when the shape is less than 1 and 0 when it is greater. When
+// the shape is exactly 1 the density is the given limit
+func densityAtBoundary(shape float64, limit float64) float64 {
+	if shape < 1 {
+		return math.Inf(1)
+	} else if shape > 1 {
+		return 0
+	}
+	return limit
+}
+
+// inversePositiveCDF finds the number whose cdf is the given probability
+// for a distribution of non-negative numbers, doubling the top of the
+// search range until it holds the answer and then searching by bisection
+func inversePositiveCDF(cdf func(float64) float64, probability float64, tolerance float64) float64 {
+	if math.IsNaN(probability) {
+		return math.NaN()
+	} else if probability <= 0 {
+		return 0
+	} else if probability >= 1 {
+		return math.Inf(1)
+	}
+	hi := 10.0
+	for cdf(hi) < probability && !math.IsInf(hi, 1) {
+		hi *= 2
+	}
+	return bisect(cdf, probability, 0, hi, tolerance)
+}
@@ -1,6 +1,9 @@
 package mlscratchlib
 
-import "testing"
+import (
+	"math"
+	"testing"
+)
 
 func TestUniformProbabilityDistribution(t *testing.T) {
 	var expected, result float64
@@ -60,6 +63,16 @@ func TestNormalProbabilityDistribution(t *testing.T) {
 	if result != expected {
 		t.Errorf("\nExpected: %f\nGot: %f", expected, result)
 	}
+
+	// with a sigma of 2, x = 3 is 1 standard deviation from a mean of 1, so
+	// the density is the standard normal density at 1 divided by sigma.
+	// The exponent divides by 2 * sigma^2, not by 2 and then times sigma^2
+	result = NormalProbabilityDistribution(3, 1, 2)
+	expected = 0.24197072451914337 / 2
+
+	if !almostEqual(result, expected, 1e-15) {
+		t.Errorf("\nExpected: %v\nGot: %v", expected, result)
+	}
 }
 
 func TestNormalCDF(t *testing.T) {
@@ -132,3 +145,235 @@ func TestInverseNormalCDF(t *testing.T) {
 		t.Errorf("\nExpected: %f\nGot: %f", expected, result)
 	}
 }
+
+func TestStudentTDistribution(t *testing.T) {
+	var expected, result float64
+
+	// one degree of freedom is the Cauchy distribution
+	result = StudentTPDF(0, 1)
+	expected = 1 / math.Pi
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	result = StudentTCDF(1, 1)
+	expected = 0.75
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	// infinite degrees of freedom is the normal distribution
+	result = StudentTCDF(1.5, math.Inf(1))
+	expected = NormalCDF(1.5, 0, 1)
+
+	if result != expected {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	if result = StudentTPDF(1.5, 0); !math.IsNaN(result) { // test for invalid degrees of freedom
+		t.Errorf("Expected NaN for 0 degrees of freedom, got %v", result)
+	}
+
+	// critical values from a t table
+	cases := []struct{ probability, df, expected float64 }{
+		{0.975, 1, 12.706204736174698},
+		{0.975, 7, 2.364624251592785},
+		{0.95, 30, 1.6972608865939587},
+		{0.025, 7, -2.364624251592785},
+		{0.5, 3, 0},
+		{0.975, math.Inf(1), 1.959963984540054},
+	}
+
+	for _, c := range cases {
+		result = InverseStudentTCDF(c.probability, c.df, 0)
+
+		if !almostEqual(result, c.expected, 1e-9) {
+			t.Errorf("Expected result of:\n%v\ngot result:\n%v", c.expected, result)
+		}
+
+		if !almostEqual(StudentTCDF(result, c.df), c.probability, 1e-12) {
+			t.Errorf("Expected result of:\n%v\ngot result:\n%v", c.probability, StudentTCDF(result, c.df))
+		}
+	}
+
+	// a coarse tolerance stops the search early
+	result = InverseStudentTCDF(0.975, 7, 0.01)
+	expected = 2.364624251592785
+
+	if math.Abs(result-expected) > 0.01 {
+		t.Errorf("Expected result within 0.01 of:\n%v\ngot result:\n%v", expected, result)
+	}
+}
+
+func TestGammaDistribution(t *testing.T) {
+	var expected, result float64
+
+	// an integer shape is the Erlang distribution, whose CDF is a Poisson tail
+	result = GammaCDF(4, 3, 2)
+	expected = 1 - math.Exp(-2)*(1+2+2)
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	result = GammaPDF(4, 3, 2)
+	expected = 4 * 4 * math.Exp(-2) / (2 * 8)
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	// a shape of 1 is the exponential distribution, whose density at 0 is the rate
+	result = GammaPDF(0, 1, 4)
+	expected = 0.25
+
+	if result != expected {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	if result = GammaPDF(-1, 2, 1); result != 0 {
+		t.Errorf("Expected a density of 0 for a negative number, got %v", result)
+	}
+
+	if result = GammaCDF(1, -2, 1); !math.IsNaN(result) {
+		t.Errorf("Expected NaN for a negative shape, got %v", result)
+	}
+
+	for _, probability := range []float64{0.001, 0.3, 0.5, 0.99} {
+		result = InverseGammaCDF(probability, 0.7, 3, 0)
+
+		if !almostEqual(GammaCDF(result, 0.7, 3), probability, 1e-12) {
+			t.Errorf("Expected result of:\n%v\ngot result:\n%v", probability, GammaCDF(result, 0.7, 3))
+		}
+	}
+}
+
+func TestChiSquareDistribution(t *testing.T) {
+	var expected, result float64
+
+	// two degrees of freedom is an exponential distribution with mean 2
+	result = ChiSquarePDF(2, 2)
+	expected = math.Exp(-1) / 2
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	// one degree of freedom is the square of a standard normal
+	result = ChiSquareCDF(2.25, 1)
+	expected = 2*NormalCDF(1.5, 0, 1) - 1
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	// critical values from a chi-square table
+	cases := []struct{ probability, df, expected float64 }{
+		{0.95, 1, 3.841458820694124},
+		{0.95, 10, 18.307038053275146},
+		{0.05, 4, 0.7107230213973239},
+	}
+
+	for _, c := range cases {
+		result = InverseChiSquareCDF(c.probability, c.df, 0)
+
+		if !almostEqual(result, c.expected, 1e-9) {
+			t.Errorf("Expected result of:\n%v\ngot result:\n%v", c.expected, result)
+		}
+	}
+
+	if result = InverseChiSquareCDF(1, 3, 0); !math.IsInf(result, 1) {
+		t.Errorf("Expected +Inf for a probability of 1, got %v", result)
+	}
+}
+
+func TestFDistribution(t *testing.T) {
+	var expected, result float64
+
+	// an F(1, df) variable is the square of a t variable with df degrees of freedom
+	result = FCDF(4, 1, 10)
+	expected = 2*StudentTCDF(2, 10) - 1
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	result = FPDF(4, 1, 10)
+	expected = StudentTPDF(2, 10) / 2
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	// F(2, 2) has the CDF x / (1 + x) and a density of 1 at 0
+	result = FCDF(3, 2, 2)
+	expected = 0.75
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	if result = FPDF(0, 2, 2); result != 1 {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", 1, result)
+	}
+
+	result = InverseFCDF(0.95, 1, 10, 0)
+	expected = InverseStudentTCDF(0.975, 10, 0) * InverseStudentTCDF(0.975, 10, 0)
+
+	if !almostEqual(result, expected, 1e-9) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	if result = FCDF(1, 0, 2); !math.IsNaN(result) {
+		t.Errorf("Expected NaN for 0 degrees of freedom, got %v", result)
+	}
+}
+
+func TestBetaDistribution(t *testing.T) {
+	var expected, result float64
+
+	result = BetaPDF(0.5, 2, 2)
+	expected = 1.5
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	result = BetaCDF(0.4, 2, 3)
+	expected = 0.5248
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	// densities at the ends of the support
+	if result = BetaPDF(0, 1, 3); result != 3 {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", 3, result)
+	}
+
+	if result = BetaPDF(1, 2, 0.5); !math.IsInf(result, 1) {
+		t.Errorf("Expected +Inf, got %v", result)
+	}
+
+	if result = BetaPDF(1.5, 2, 2); result != 0 {
+		t.Errorf("Expected a density of 0 outside [0, 1], got %v", result)
+	}
+
+	// beta(1, 1) is uniform
+	result = InverseBetaCDF(0.3, 1, 1, 0)
+	expected = 0.3
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	for _, probability := range []float64{0.01, 0.5, 0.9} {
+		result = InverseBetaCDF(probability, 0.5, 4, 0)
+
+		if !almostEqual(BetaCDF(result, 0.5, 4), probability, 1e-12) {
+			t.Errorf("Expected result of:\n%v\ngot result:\n%v", probability, BetaCDF(result, 0.5, 4))
+		}
+	}
+}
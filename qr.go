@@ -0,0 +1,177 @@
+package mlscratchlib
+
+import (
+	"errors"
+	"math"
+)
+
+// QR holds the Householder QR factorization of an m x n matrix A with
+// m >= n so that A = Q*R, where Q is m x n with orthonormal columns and
+// R is n x n upper triangular
+type QR struct {
+	qr    *Matrix   // Householder vectors on and below the diagonal, R above it
+	rdiag []float64 // the diagonal of R
+}
+
+// QRDecompose accepts a matrix with at least as many rows as columns
+// and returns its QR factorization computed with Householder reflections
+func QRDecompose(a *Matrix) (*QR, error) {
+	m, n := a.rows, a.cols
+	if m < n {
+		return nil, errors.New("the matrix must have at least as many rows as columns")
+	}
+	qr := a.Copy()
+	rdiag := make([]float64, n)
+
+	for k := 0; k < n; k++ {
+		// the norm of the kth column from the diagonal down
+		var norm float64
+		for i := k; i < m; i++ {
+			norm = math.Hypot(norm, qr.data[i*n+k])
+		}
+
+		if norm != 0 {
+			// pick the sign that avoids cancellation when forming the reflector
+			if qr.data[k*n+k] < 0 {
+				norm = -norm
+			}
+			for i := k; i < m; i++ {
+				qr.data[i*n+k] /= norm
+			}
+			qr.data[k*n+k]++
+
+			// apply the reflection to the remaining columns
+			for j := k + 1; j < n; j++ {
+				var s float64
+				for i := k; i < m; i++ {
+					s += qr.data[i*n+k] * qr.data[i*n+j]
+				}
+				s = -s / qr.data[k*n+k]
+				for i := k; i < m; i++ {
+					qr.data[i*n+j] += s * qr.data[i*n+k]
+				}
+			}
+		}
+		rdiag[k] = -norm
+	}
+
+	return &QR{qr: qr, rdiag: rdiag}, nil
+}
+
+// IsFullRank reports whether every column of the factorized matrix
+// is linearly independent of the others
+func (f *QR) IsFullRank() bool {
+	var maxDiagonal float64
+	for _, element := range f.rdiag {
+		maxDiagonal = math.Max(maxDiagonal, math.Abs(element))
+	}
+	tolerance := float64(f.qr.rows) * machineEpsilon * maxDiagonal
+	for _, element := range f.rdiag {
+		if math.Abs(element) <= tolerance {
+			return false
+		}
+	}
+	return true
+}
+
+// Q returns the m x n matrix with orthonormal columns
+func (f *QR) Q() *Matrix {
+	m, n := f.qr.rows, f.qr.cols
+	q := NewMatrix(m, n)
+	for k := n - 1; k >= 0; k-- {
+		q.data[k*n+k] = 1
+		for j := k; j < n; j++ {
+			if f.qr.data[k*n+k] == 0 {
+				continue
+			}
+			var s float64
+			for i := k; i < m; i++ {
+				s += f.qr.data[i*n+k] * q.data[i*n+j]
+			}
+			s = -s / f.qr.data[k*n+k]
+			for i := k; i < m; i++ {
+				q.data[i*n+j] += s * f.qr.data[i*n+k]
+			}
+		}
+	}
+	return q
+}
+
+// R returns the n x n upper triangular factor
+func (f *QR) R() *Matrix {
+	n := f.qr.cols
+	return NewMatrixFromFunction(n, n, func(i int, j int) float64 {
+		if i < j {
+			return f.qr.data[i*n+j]
+		} else if i == j {
+			return f.rdiag[i]
+		}
+		return 0
+	})
+}
+
+// Solve accepts a vector b with one element for every row of A and
+// returns the vector x that minimizes the length of A*x - b
+func (f *QR) Solve(b []float64) ([]float64, error) {
+	m, n := f.qr.rows, f.qr.cols
+	if len(b) != m {
+		return nil, errors.New("the vector must have one element for every row of the matrix")
+	}
+	if !f.IsFullRank() {
+		return nil, errors.New("the matrix is rank deficient")
+	}
+
+	// compute Q^T * b by applying each reflection in turn
+	y := make([]float64, m)
+	copy(y, b)
+	for k := 0; k < n; k++ {
+		var s float64
+		for i := k; i < m; i++ {
+			s += f.qr.data[i*n+k] * y[i]
+		}
+		s = -s / f.qr.data[k*n+k]
+		for i := k; i < m; i++ {
+			y[i] += s * f.qr.data[i*n+k]
+		}
+	}
+
+	// back substitution to solve R*x = Q^T * b
+	x := y[:n]
+	for i := n - 1; i >= 0; i-- {
+		sum := x[i]
+		for j := i + 1; j < n; j++ {
+			sum -= f.qr.data[i*n+j] * x[j]
+		}
+		x[i] = sum / f.rdiag[i]
+	}
+	return x, nil
+}
+
+// LeastSquares accepts a matrix a with at least as many rows as columns
+// and a vector b, and returns the coefficient vector x that minimizes
+// the length of a*x - b along with the residual sum of squares. It
+// never forms a^T * a, so it stays accurate for ill conditioned data
+func LeastSquares(a *Matrix, b []float64) (coefficients []float64, residualSumOfSquares float64, err error) {
+	f, err := QRDecompose(a)
+	if err != nil {
+		return nil, 0, err
+	}
+	coefficients, err = f.Solve(b)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	predicted, err := a.MulVector(coefficients)
+	if err != nil {
+		return nil, 0, err
+	}
+	residuals, err := SubtractVector(b, predicted)
+	if err != nil {
+		return nil, 0, err
+	}
+	residualSumOfSquares, err = SumofSquares(residuals)
+	if err != nil {
+		return nil, 0, err
+	}
+	return coefficients, residualSumOfSquares, nil
+}
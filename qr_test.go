@@ -0,0 +1,81 @@
+package mlscratchlib
+
+import "testing"
+
+// design matrix for fitting y = intercept + slope*x to x = 0, 1, 2, 3
+var design4x2 = [][]float64{{1, 0}, {1, 1}, {1, 2}, {1, 3}}
+
+func TestQRDecompose(t *testing.T) {
+	a := mustMatrix(t, design4x2)
+
+	f, err := QRDecompose(a)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	result, _ := f.Q().Mul(f.R())
+
+	if !matricesAlmostEqual(result, a, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", design4x2, result.ToSlices())
+	}
+
+	q := f.Q()
+	qtq, _ := q.Transpose().Mul(q) // the columns of Q are orthonormal
+
+	if !matricesAlmostEqual(qtq, Identity(2), 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", Identity(2).ToSlices(), qtq.ToSlices())
+	}
+
+	r := f.R()
+	if r.At(1, 0) != 0 {
+		t.Errorf("R is not upper triangular: %v", r.ToSlices())
+	}
+
+	f, err = QRDecompose(mustMatrix(t, matrix2x3)) // test the wide matrix logic
+
+	if err == nil || f != nil {
+		t.Errorf("Function accepted a matrix with more columns than rows")
+	}
+}
+
+func TestLeastSquares(t *testing.T) {
+	a := mustMatrix(t, design4x2)
+
+	result, rss, err := LeastSquares(a, []float64{1, 3, 2, 5})
+	expected := []float64{1.1, 1.1}
+	expectedRSS := 2.7
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !vectorsAlmostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	if !almostEqual(rss, expectedRSS, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expectedRSS, rss)
+	}
+
+	// a square system with an exact solution has no residual
+	result, rss, err = LeastSquares(mustMatrix(t, square3a), []float64{5, -2, 9})
+	expected = []float64{1, 1, 2}
+
+	if !vectorsAlmostEqual(result, expected, 1e-12) || !almostEqual(rss, 0, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v with rss %v", expected, result, rss)
+	}
+
+	// the second column is twice the first so the fit is not unique
+	result, rss, err = LeastSquares(mustMatrix(t, [][]float64{{1, 2}, {2, 4}, {3, 6}}), []float64{1, 2, 3})
+
+	if err == nil || result != nil {
+		t.Errorf("Function accepted a rank deficient matrix, got %v", result)
+	}
+
+	result, rss, err = LeastSquares(a, vec8a) // test the length mismatch logic
+
+	if err == nil || result != nil {
+		t.Errorf("Function accepted a vector of the wrong length, got %v", result)
+	}
+}
@@ -0,0 +1,145 @@
+package mlscratchlib
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// QuantileMethod selects one of the nine sample quantile definitions
+// from Hyndman and Fan, "Sample Quantiles in Statistical Packages" (1996).
+// The numbering matches the type argument of R's quantile function
+type QuantileMethod int
+
+const (
+	// QuantileType1 is the inverse of the empirical CDF
+	QuantileType1 QuantileMethod = iota + 1
+	// QuantileType2 is like type 1 but averages at discontinuities
+	QuantileType2
+	// QuantileType3 returns the nearest even order statistic (SAS)
+	QuantileType3
+	// QuantileType4 linearly interpolates the empirical CDF
+	QuantileType4
+	// QuantileType5 is a piecewise linear function with knots half way
+	// between the data points (Hazen)
+	QuantileType5
+	// QuantileType6 uses p(k) = k / (n + 1) (Weibull, Minitab, SPSS)
+	QuantileType6
+	// QuantileType7 uses p(k) = (k - 1) / (n - 1), it is the default in
+	// R, NumPy and Excel
+	QuantileType7
+	// QuantileType8 is approximately median unbiased whatever the
+	// distribution, Hyndman and Fan recommend it
+	QuantileType8
+	// QuantileType9 is approximately unbiased for normally distributed data
+	QuantileType9
+)
+
+// quantileFuzz absorbs rounding error in n*p so that, for example,
+// 0.1 * 10 lands on the data point 1 rather than just below it
+var quantileFuzz = 4 * machineEpsilon
+
+// Quantile accepts a vector, a decimal between 0 and 1 and a quantile
+// method and returns the sample quantile of the vector. Unlike
+// QuantileVector it interpolates between elements, and percentiles of
+// 0 and 1 return the smallest and largest elements. The caller's vector
+// is left in its original order
+func Quantile(vector []float64, percentile float64, method QuantileMethod) (float64, error) {
+	quantiles, err := Quantiles(vector, []float64{percentile}, method)
+	if err != nil {
+		return 0, err
+	}
+	return quantiles[0], nil
+}
+
+// Quantiles works like Quantile for many percentiles at once, it only
+// sorts the vector a single time
+func Quantiles(vector []float64, percentiles []float64, method QuantileMethod) ([]float64, error) {
+	if len(vector) < 1 {
+		return nil, errors.New("something went wrong vector length is 0")
+	}
+	if method < QuantileType1 || method > QuantileType9 {
+		return nil, errors.New("quantile method must be one of types 1 through 9")
+	}
+	for _, percentile := range percentiles {
+		if percentile < 0 || percentile > 1 || math.IsNaN(percentile) {
+			return nil, errors.New("percentile must be a decimal between 0 and 1")
+		}
+	}
+	sorted := copyVector(vector)
+	sort.Float64s(sorted)
+
+	quantiles := make([]float64, len(percentiles))
+	for i, percentile := range percentiles {
+		quantiles[i] = sortedQuantile(sorted, percentile, method)
+	}
+	return quantiles, nil
+}
+
+// sortedQuantile returns the quantile of a vector that is already sorted
+// from low to high. Every type is expressed as in Hyndman and Fan: with
+// j = floor(n*p + m) and g = n*p + m - j, the quantile is
+// (1 - gamma) * x[j] + gamma * x[j+1] using 1 based order statistics
+// clamped to x[1] and x[n]
+func sortedQuantile(sorted []float64, p float64, method QuantileMethod) float64 {
+	n := float64(len(sorted))
+
+	// at returns the 1 based order statistic, clamped to the ends
+	at := func(j float64) float64 {
+		if j < 1 {
+			return sorted[0]
+		} else if j > n {
+			return sorted[len(sorted)-1]
+		}
+		return sorted[int(j)-1]
+	}
+
+	var m float64
+	switch method {
+	case QuantileType1, QuantileType2, QuantileType4:
+		m = 0
+	case QuantileType3:
+		m = -0.5
+	case QuantileType5:
+		m = 0.5
+	case QuantileType6:
+		m = p
+	case QuantileType7:
+		m = 1 - p
+	case QuantileType8:
+		m = (p + 1) / 3
+	case QuantileType9:
+		m = p/4 + 3.0/8
+	}
+
+	position := n*p + m
+	j := math.Floor(position + quantileFuzz)
+	g := position - j
+	if math.Abs(g) < quantileFuzz {
+		g = 0
+	}
+
+	var gamma float64
+	switch method {
+	case QuantileType1:
+		if g > 0 {
+			gamma = 1
+		}
+	case QuantileType2:
+		gamma = 0.5
+		if g > 0 {
+			gamma = 1
+		}
+	case QuantileType3:
+		if g > 0 || math.Mod(j, 2) != 0 {
+			gamma = 1
+		}
+	default:
+		gamma = g
+	}
+
+	if gamma == 0 {
+		return at(j)
+	}
+	return at(j) + gamma*(at(j+1)-at(j))
+}
@@ -0,0 +1,104 @@
+package mlscratchlib
+
+import "testing"
+
+func TestQuantile(t *testing.T) {
+	// vec8a sorted is {11, 13, 42, 66, 75, 97, 99, 100}, the expected values
+	// match R's quantile(x, p, type = method)
+	cases := []struct {
+		method     QuantileMethod
+		percentile float64
+		expected   float64
+	}{
+		{QuantileType1, 0.25, 13},
+		{QuantileType1, 0.5, 66},
+		{QuantileType1, 0.9, 100},
+		{QuantileType2, 0.25, 27.5},
+		{QuantileType3, 0.25, 13},
+		{QuantileType4, 0.25, 13},
+		{QuantileType4, 0.5, 66},
+		{QuantileType4, 0.9, 99.2},
+		{QuantileType5, 0.25, 27.5},
+		{QuantileType6, 0.25, 20.25},
+		{QuantileType6, 0.5, 70.5},
+		{QuantileType6, 0.9, 100},
+		{QuantileType7, 0.25, 34.75},
+		{QuantileType7, 0.5, 70.5},
+		{QuantileType7, 0.9, 99.3},
+		{QuantileType8, 0.25, 13 + 29*5.0/12},
+		{QuantileType8, 0.5, 70.5},
+		{QuantileType8, 0.9, 99 + 5.0/6},
+		{QuantileType9, 0.25, 25.6875},
+	}
+
+	for _, c := range cases {
+		result, err := Quantile(vec8a, c.percentile, c.method)
+
+		if err != nil {
+			t.Errorf("Error: %v", err)
+		}
+
+		if !almostEqual(result, c.expected, 1e-9) {
+			t.Errorf("type %d p = %v\nExpected result of:\n%v\ngot result:\n%v", c.method, c.percentile, c.expected, result)
+		}
+	}
+}
+
+func TestQuantileEndpoints(t *testing.T) {
+	// every method returns the smallest and largest element at 0 and 1
+	for method := QuantileType1; method <= QuantileType9; method++ {
+		result, err := Quantiles(vec8a, []float64{0, 1}, method)
+		expected := []float64{11, 100}
+
+		if err != nil {
+			t.Errorf("Error: %v", err)
+		}
+
+		if !vectorsAlmostEqual(result, expected, 0) {
+			t.Errorf("type %d\nExpected result of:\n%v\ngot result:\n%v", method, expected, result)
+		}
+	}
+
+	// QuantileVector used to index past the end of the vector at 1
+	result, err := QuantileVector(vec8a, 1)
+	expected := 100.0
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if result != expected {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+}
+
+func TestQuantiles(t *testing.T) {
+	result, err := Quantiles(vec8b, []float64{0.1, 0.5, 0.75}, QuantileType7)
+	expected := []float64{1.7, 4.5, 6.25}
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !vectorsAlmostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	result, err = Quantiles([]float64{}, []float64{0.5}, QuantileType7) // test for an empty vector
+
+	if err == nil || result != nil {
+		t.Errorf("Function accepted an empty vector")
+	}
+
+	result, err = Quantiles(vec8b, []float64{0.5, 1.1}, QuantileType7) // test the percentile range logic
+
+	if err == nil || result != nil {
+		t.Errorf("Function accepted a percentile greater than 1")
+	}
+
+	result, err = Quantiles(vec8b, []float64{0.5}, QuantileMethod(10)) // test for an unknown method
+
+	if err == nil || result != nil {
+		t.Errorf("Function accepted an unknown quantile method")
+	}
+}
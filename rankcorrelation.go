@@ -0,0 +1,174 @@
+package mlscratchlib
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// CorrelationResult holds a correlation coefficient together with the
+// two-sided p-value for the null hypothesis that the true correlation is 0.
+// PValue is NaN when there are too few pairs to estimate it
+type CorrelationResult struct {
+	Coefficient float64
+	PValue      float64
+}
+
+// RankVector accepts a vector and returns the rank of each element, 1 for
+// the smallest up to len(vector) for the largest. Tied elements all get
+// the average of the ranks they span, so {10, 20, 20, 30} ranks as
+// {1, 2.5, 2.5, 4}
+func RankVector(vector []float64) []float64 {
+	order := make([]int, len(vector))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i int, j int) bool { return vector[order[i]] < vector[order[j]] })
+
+	ranks := make([]float64, len(vector))
+	for start := 0; start < len(order); {
+		end := start + 1
+		for end < len(order) && vector[order[end]] == vector[order[start]] {
+			end++
+		}
+		// positions start..end-1 are ranks start+1..end, so their mean is
+		average := float64(start+end+1) / 2
+		for _, i := range order[start:end] {
+			ranks[i] = average
+		}
+		start = end
+	}
+	return ranks
+}
+
+// fisherPValue returns the two-sided p-value of a correlation coefficient
+// r from pairs values, using the Fisher transformation atanh(r) which is
+// approximately normal with the given variance times 1 / (pairs - 3)
+func fisherPValue(r float64, pairs float64, variance float64) float64 {
+	if pairs <= 3 {
+		return math.NaN()
+	}
+	// rounding can push a perfect correlation just past 1
+	r = math.Max(-1, math.Min(1, r))
+	return twoSidedNormalPValue(math.Atanh(r) * math.Sqrt((pairs-3)/variance))
+}
+
+// twoSidedNormalPValue returns the probability that a standard normal
+// variable is at least as far from 0 as z
+func twoSidedNormalPValue(z float64) float64 {
+	return 2 * NormalCDF(-math.Abs(z), 0, 1)
+}
+
+// PearsonCorrelation accepts two vectors and returns Pearson's r, as
+// computed by Correlation, along with a p-value from the Fisher
+// transformation atanh(r) * sqrt(n - 3), which is approximately standard
+// normal when the vectors are uncorrelated
+func PearsonCorrelation(a []float64, b []float64) (CorrelationResult, error) {
+	r, err := Correlation(a, b)
+	if err != nil {
+		return CorrelationResult{}, err
+	}
+	return CorrelationResult{Coefficient: r, PValue: fisherPValue(r, float64(len(a)), 1)}, nil
+}
+
+// SpearmanCorrelation accepts two vectors and returns Spearman's rho, which
+// is the Pearson correlation of their ranks with ties given average ranks.
+// It measures how well the relationship between the vectors is described by
+// any monotonic function, not just a line. The p-value uses the Fisher
+// transformation with the variance 1.06 / (n - 3) suggested by Fieller,
+// Hartley and Pearson (1957)
+func SpearmanCorrelation(a []float64, b []float64) (CorrelationResult, error) {
+	if len(a) != len(b) {
+		return CorrelationResult{}, errors.New("vectors must be the same length")
+	}
+	rho, err := Correlation(RankVector(a), RankVector(b))
+	if err != nil {
+		return CorrelationResult{}, err
+	}
+	return CorrelationResult{Coefficient: rho, PValue: fisherPValue(rho, float64(len(a)), 1.06)}, nil
+}
+
+// KendallTau accepts two vectors and returns Kendall's tau-b, which
+// compares every pair of positions and counts how often the two vectors
+// order them the same way (concordant) versus the opposite way
+// (discordant), adjusted for ties. The p-value comes from the normal
+// approximation to the number of concordant minus discordant pairs, using
+// its exact variance under independence with ties
+func KendallTau(a []float64, b []float64) (CorrelationResult, error) {
+	if len(a) != len(b) {
+		return CorrelationResult{}, errors.New("vectors must be the same length")
+	} else if len(a) < 2 {
+		return CorrelationResult{}, errors.New("kendall's tau needs at least 2 pairs")
+	}
+
+	// S is the number of concordant minus discordant pairs
+	var s float64
+	for i := range a {
+		for j := i + 1; j < len(a); j++ {
+			s += sign(a[i]-a[j]) * sign(b[i]-b[j])
+		}
+	}
+
+	n := float64(len(a))
+	pairs := n * (n - 1) / 2
+	aTies, bTies := tieCounts(a), tieCounts(b)
+	var aTiedPairs, bTiedPairs float64
+	for _, t := range aTies {
+		aTiedPairs += t * (t - 1) / 2
+	}
+	for _, u := range bTies {
+		bTiedPairs += u * (u - 1) / 2
+	}
+	if aTiedPairs == pairs || bTiedPairs == pairs {
+		return CorrelationResult{}, errors.New("correlation is undefined when a vector has a standard deviation of 0")
+	}
+	tau := s / math.Sqrt((pairs-aTiedPairs)*(pairs-bTiedPairs))
+
+	// the variance of S under independence, corrected for ties in both vectors
+	var vt, vu, t1, u1, t2, u2 float64
+	for _, t := range aTies {
+		vt += t * (t - 1) * (2*t + 5)
+		t1 += t * (t - 1)
+		t2 += t * (t - 1) * (t - 2)
+	}
+	for _, u := range bTies {
+		vu += u * (u - 1) * (2*u + 5)
+		u1 += u * (u - 1)
+		u2 += u * (u - 1) * (u - 2)
+	}
+	variance := (n*(n-1)*(2*n+5)-vt-vu)/18 + t1*u1/(2*n*(n-1))
+	if n > 2 {
+		variance += t2 * u2 / (9 * n * (n - 1) * (n - 2))
+	}
+
+	return CorrelationResult{Coefficient: tau, PValue: twoSidedNormalPValue(s / math.Sqrt(variance))}, nil
+}
+
+// tieCounts returns the size of every group of equal elements in vector
+// that has more than one member
+func tieCounts(vector []float64) []float64 {
+	sorted := copyVector(vector)
+	sort.Float64s(sorted)
+	var counts []float64
+	for start := 0; start < len(sorted); {
+		end := start + 1
+		for end < len(sorted) && sorted[end] == sorted[start] {
+			end++
+		}
+		if end-start > 1 {
+			counts = append(counts, float64(end-start))
+		}
+		start = end
+	}
+	return counts
+}
+
+// sign returns -1, 0 or 1 depending on the sign of x
+func sign(x float64) float64 {
+	if x > 0 {
+		return 1
+	} else if x < 0 {
+		return -1
+	}
+	return 0
+}
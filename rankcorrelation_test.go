@@ -0,0 +1,132 @@
+package mlscratchlib
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestRankVector(t *testing.T) {
+	var expected, result []float64
+
+	result = RankVector([]float64{10, 30, 20, 20})
+	expected = []float64{1, 4, 2.5, 2.5}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	result = RankVector([]float64{5, 5, 5})
+	expected = []float64{2, 2, 2}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+}
+
+func TestCorrelationZeroVariance(t *testing.T) {
+	// a constant vector has no correlation with anything, it used to return 0
+	result, err := Correlation([]float64{3, 3, 3, 3}, vec8b[:4])
+
+	if err == nil {
+		t.Errorf("Function accepted a vector with a standard deviation of 0, got %v", result)
+	}
+}
+
+func TestPearsonCorrelation(t *testing.T) {
+	result, err := PearsonCorrelation(vec8c, vec8b)
+	expected, _ := Correlation(vec8c, vec8b)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if result.Coefficient != expected {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result.Coefficient)
+	}
+
+	expectedP := 2 * NormalCDF(-math.Atanh(expected)*math.Sqrt(5), 0, 1)
+
+	if !almostEqual(result.PValue, expectedP, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expectedP, result.PValue)
+	}
+
+	result, err = PearsonCorrelation([]float64{1, 2, 3}, []float64{2, 1, 3}) // too few pairs for a p-value
+
+	if err != nil || !math.IsNaN(result.PValue) {
+		t.Errorf("Expected a NaN p-value for 3 pairs, got %v (%v)", result.PValue, err)
+	}
+}
+
+func TestSpearmanCorrelation(t *testing.T) {
+	// any monotonic relationship has a rank correlation of 1
+	x := []float64{1, 2, 3, 4, 5, 6, 7, 8}
+	y := []float64{1, 8, 27, 64, 125, 216, 343, 512}
+
+	result, err := SpearmanCorrelation(x, y)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !almostEqual(result.Coefficient, 1, 1e-12) || result.PValue != 0 {
+		t.Errorf("Expected a coefficient of 1 with p-value 0, got %v", result)
+	}
+
+	// with ties, the value from scipy.stats.spearmanr
+	result, err = SpearmanCorrelation([]float64{1, 2, 3, 4, 5}, []float64{5, 6, 7, 8, 7})
+	expected := 0.8207826816681233
+
+	if !almostEqual(result.Coefficient, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result.Coefficient)
+	}
+
+	if result.PValue <= 0 || result.PValue >= 1 {
+		t.Errorf("Expected a p-value between 0 and 1, got %v", result.PValue)
+	}
+
+	_, err = SpearmanCorrelation([]float64{1, 1, 1, 1}, x[:4]) // test for zero variance
+
+	if err == nil {
+		t.Errorf("Function accepted a vector with a standard deviation of 0")
+	}
+
+	_, err = SpearmanCorrelation(vec8a, vec10a) // test the length mismatch logic
+
+	if err == nil {
+		t.Errorf("Function accepted vectors of mismatched lengths")
+	}
+}
+
+func TestKendallTau(t *testing.T) {
+	// the example from scipy.stats.kendalltau, which has ties in both vectors
+	result, err := KendallTau([]float64{12, 2, 1, 12, 2}, []float64{1, 4, 7, 1, 0})
+	expected := CorrelationResult{Coefficient: -0.47140452079103173, PValue: 0.2827454599327748}
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !almostEqual(result.Coefficient, expected.Coefficient, 1e-12) || !almostEqual(result.PValue, expected.PValue, 1e-9) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	// perfectly reversed order is a tau of -1
+	result, err = KendallTau(vec8b, ScalarMultiply(-1, vec8b))
+
+	if !almostEqual(result.Coefficient, -1, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", -1, result.Coefficient)
+	}
+
+	_, err = KendallTau([]float64{1, 1, 1}, []float64{1, 2, 3}) // test for zero variance
+
+	if err == nil {
+		t.Errorf("Function accepted a vector with a standard deviation of 0")
+	}
+
+	_, err = KendallTau(vec8a, vec10a) // test the length mismatch logic
+
+	if err == nil {
+		t.Errorf("Function accepted vectors of mismatched lengths")
+	}
+}
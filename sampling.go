@@ -0,0 +1,234 @@
+package mlscratchlib
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// NewRand returns a random number generator seeded with seed. Every
+// function in this package that takes a *rand.Rand draws only from it, so
+// passing generators made from the same seed reproduces an experiment
+// exactly. Passing nil instead uses the global source in math/rand, which
+// is not reproducible
+func NewRand(seed int64) *rand.Rand {
+	return rand.New(rand.NewSource(seed))
+}
+
+// randIntn returns a uniform int in [0, n) from rng, or from the global
+// source when rng is nil
+func randIntn(rng *rand.Rand, n int) int {
+	if rng == nil {
+		return rand.Intn(n)
+	}
+	return rng.Intn(n)
+}
+
+// randOpenFloat64 returns a uniform number in (0, 1), redrawing the 0 that
+// randFloat64 can return so that logs and quantile functions stay finite
+func randOpenFloat64(rng *rand.Rand) float64 {
+	for {
+		if u := randFloat64(rng); u != 0 {
+			return u
+		}
+	}
+}
+
+// BoxMuller returns two independent standard normal numbers made from two
+// uniform numbers with the Box-Muller transform
+// https://en.wikipedia.org/wiki/Box%E2%80%93Muller_transform
+func BoxMuller(rng *rand.Rand) (float64, float64) {
+	radius := math.Sqrt(-2 * math.Log(randOpenFloat64(rng)))
+	angle := 2 * math.Pi * randFloat64(rng)
+	return radius * math.Cos(angle), radius * math.Sin(angle)
+}
+
+// the ziggurat covers the normal density exp(-x*x/2) with 128 layers of
+// equal area zigguratArea, the bottom one including the tail beyond
+// zigguratR. These constants are from Marsaglia and Tsang (2000)
+const (
+	zigguratLayers = 128
+	zigguratR      = 3.442619855899
+	zigguratArea   = 9.91256303526217e-3
+)
+
+// zigguratX holds the right edge of each layer, from the widest at the
+// bottom to 0 at the top
+var zigguratX = zigguratEdges()
+
+// zigguratEdges computes the layer edges, each of which is chosen so that
+// its rectangle has an area of zigguratArea
+func zigguratEdges() []float64 {
+	density := func(x float64) float64 { return math.Exp(-x * x / 2) }
+	x := make([]float64, zigguratLayers+1)
+	x[0] = zigguratArea / density(zigguratR)
+	x[1] = zigguratR
+	for i := 1; i < zigguratLayers-1; i++ {
+		x[i+1] = math.Sqrt(-2 * math.Log(zigguratArea/x[i]+density(x[i])))
+	}
+	x[zigguratLayers] = 0
+	return x
+}
+
+// Ziggurat returns a standard normal number using the ziggurat method of
+// Marsaglia and Tsang (2000), which is usually faster than BoxMuller
+// because most draws need only one uniform number and a comparison
+// https://en.wikipedia.org/wiki/Ziggurat_algorithm
+func Ziggurat(rng *rand.Rand) float64 {
+	for {
+		layer := randIntn(rng, zigguratLayers)
+		x := (2*randFloat64(rng) - 1) * zigguratX[layer]
+		if math.Abs(x) < zigguratX[layer+1] {
+			// inside the part of the layer that is under the curve everywhere
+			return x
+		}
+		if layer == 0 {
+			// the tail beyond zigguratR, by Marsaglia's method
+			for {
+				a := -math.Log(randOpenFloat64(rng)) / zigguratR
+				b := -math.Log(randOpenFloat64(rng))
+				if 2*b > a*a {
+					if x < 0 {
+						return -(zigguratR + a)
+					}
+					return zigguratR + a
+				}
+			}
+		}
+		low := math.Exp(-zigguratX[layer] * zigguratX[layer] / 2)
+		high := math.Exp(-zigguratX[layer+1] * zigguratX[layer+1] / 2)
+		if low+randFloat64(rng)*(high-low) < math.Exp(-x*x/2) {
+			return x
+		}
+	}
+}
+
+// InverseTransformSample returns a random number from the distribution
+// with the given quantile function, by passing it a uniform number in
+// (0, 1). For example
+//
+//	InverseTransformSample(func(p float64) float64 { return InverseNormalCDF(p, 0, 1, 1e-9) }, rng)
+//
+// returns a standard normal number
+func InverseTransformSample(quantile func(float64) float64, rng *rand.Rand) float64 {
+	return quantile(randOpenFloat64(rng))
+}
+
+// WeightedSampleWithReplacement accepts a non-negative weight for each
+// index and returns n indices drawn independently with probabilities
+// proportional to the weights
+func WeightedSampleWithReplacement(weights []float64, n int, rng *rand.Rand) ([]int, error) {
+	if n < 0 {
+		return nil, errors.New("the sample size must not be negative")
+	}
+	categorical, err := NewCategorical(weights)
+	if err != nil {
+		return nil, err
+	}
+	sample := make([]int, n)
+	for i := range sample {
+		sample[i] = categorical.Rand(rng)
+	}
+	return sample, nil
+}
+
+// WeightedSampleWithoutReplacement accepts a non-negative weight for each
+// index and returns n distinct indices, where each draw picks one of the
+// remaining indices with probability proportional to its weight. It gives
+// every index the key log(u) / weight for a uniform u and keeps the n
+// largest, the method of Efraimidis and Spirakis (2006). Indices with a
+// weight of 0 are never drawn
+func WeightedSampleWithoutReplacement(weights []float64, n int, rng *rand.Rand) ([]int, error) {
+	if _, err := checkWeights(len(weights), weights); err != nil {
+		return nil, err
+	}
+	type keyedIndex struct {
+		key   float64
+		index int
+	}
+	var keyed []keyedIndex
+	for i, weight := range weights {
+		if weight > 0 {
+			keyed = append(keyed, keyedIndex{key: math.Log(randOpenFloat64(rng)) / weight, index: i})
+		}
+	}
+	if n < 0 || n > len(keyed) {
+		return nil, errors.New("the sample size must be between 0 and the number of positive weights")
+	}
+	sort.SliceStable(keyed, func(i int, j int) bool { return keyed[i].key > keyed[j].key })
+	sample := make([]int, n)
+	for i := range sample {
+		sample[i] = keyed[i].index
+	}
+	return sample, nil
+}
+
+// Shuffle puts the elements of vector in a uniformly random order in place
+// with the Fisher-Yates shuffle
+// https://en.wikipedia.org/wiki/Fisher%E2%80%93Yates_shuffle
+func Shuffle(vector []float64, rng *rand.Rand) {
+	for i := len(vector) - 1; i > 0; i-- {
+		j := randIntn(rng, i+1)
+		vector[i], vector[j] = vector[j], vector[i]
+	}
+}
+
+// Permutation returns the indices 0 to n-1 in a uniformly random order,
+// which is useful for shuffling several vectors the same way, such as the
+// rows of a data set and their labels
+func Permutation(n int, rng *rand.Rand) []int {
+	permutation := make([]int, n)
+	for i := range permutation {
+		permutation[i] = i
+	}
+	for i := n - 1; i > 0; i-- {
+		j := randIntn(rng, i+1)
+		permutation[i], permutation[j] = permutation[j], permutation[i]
+	}
+	return permutation
+}
+
+// Reservoir keeps a uniform random sample of a fixed size from a stream of
+// values whose length isn't known in advance, using Algorithm R
+// https://en.wikipedia.org/wiki/Reservoir_sampling
+type Reservoir struct {
+	sample []float64
+	size   int
+	count  int
+	rng    *rand.Rand
+}
+
+// NewReservoir returns an empty reservoir that keeps up to size values and
+// draws from rng, or from the global source when rng is nil
+func NewReservoir(size int, rng *rand.Rand) (*Reservoir, error) {
+	if size < 1 {
+		return nil, errors.New("the reservoir size must be at least 1")
+	}
+	return &Reservoir{sample: make([]float64, 0, size), size: size, rng: rng}, nil
+}
+
+// Add offers a value from the stream to the reservoir. Once the reservoir
+// is full, the nth value replaces a random element with probability
+// size / n
+func (r *Reservoir) Add(value float64) {
+	r.count++
+	if len(r.sample) < r.size {
+		r.sample = append(r.sample, value)
+		return
+	}
+	if j := randIntn(r.rng, r.count); j < r.size {
+		r.sample[j] = value
+	}
+}
+
+// Count returns the number of values that have been added
+func (r *Reservoir) Count() int {
+	return r.count
+}
+
+// Sample returns a copy of the values in the reservoir, which are all of
+// the values added so far when there have been no more than size of them
+func (r *Reservoir) Sample() []float64 {
+	return copyVector(r.sample)
+}
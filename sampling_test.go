@@ -0,0 +1,178 @@
+package mlscratchlib
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestNormalSamplers(t *testing.T) {
+	const samples = 20000
+	// the 1% critical value of the Kolmogorov-Smirnov statistic
+	critical := 1.63 / math.Sqrt(samples)
+	standardNormal := func(x float64) float64 { return NormalCDF(x, 0, 1) }
+
+	samplers := map[string]func(*rand.Rand) float64{
+		"BoxMuller": func(rng *rand.Rand) float64 { x, _ := BoxMuller(rng); return x },
+		"Ziggurat":  Ziggurat,
+		"InverseTransformSample": func(rng *rand.Rand) float64 {
+			return InverseTransformSample(func(p float64) float64 { return InverseNormalCDF(p, 0, 1, 1e-9) }, rng)
+		},
+	}
+
+	for name, sampler := range samplers {
+		rng := NewRand(42)
+		sample := make([]float64, samples)
+		for i := range sample {
+			sample[i] = sampler(rng)
+		}
+
+		if result, _ := KolmogorovSmirnovTest(sample, standardNormal); result.Statistic > critical {
+			t.Errorf("%v: Kolmogorov-Smirnov statistic %v is above %v", name, result.Statistic, critical)
+		}
+
+		// the same seed gives the same numbers
+		if a, b := sampler(NewRand(9)), sampler(NewRand(9)); a != b {
+			t.Errorf("%v: expected equal draws from equal seeds, got %v and %v", name, a, b)
+		}
+	}
+
+	// the tail beyond the bottom layer of the ziggurat
+	rng := NewRand(5)
+	var tail int
+	for i := 0; i < 1000000; i++ {
+		if math.Abs(Ziggurat(rng)) > zigguratR {
+			tail++
+		}
+	}
+	expected := 2 * NormalCDF(-zigguratR, 0, 1) * 1000000
+
+	if math.Abs(float64(tail)-expected) > 5*math.Sqrt(expected) {
+		t.Errorf("Expected about %v draws beyond %v, got %v", expected, zigguratR, tail)
+	}
+}
+
+func TestWeightedSampleWithReplacement(t *testing.T) {
+	weights := []float64{1, 0, 3}
+	sample, err := WeightedSampleWithReplacement(weights, 40000, NewRand(1))
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	counts := make([]float64, len(weights))
+	for _, i := range sample {
+		counts[i]++
+	}
+
+	if counts[1] != 0 || math.Abs(counts[2]/40000-0.75) > 0.01 {
+		t.Errorf("Expected counts in proportion to the weights %v, got %v", weights, counts)
+	}
+
+	_, err = WeightedSampleWithReplacement(weights, -1, nil) // test for a negative sample size
+
+	if err == nil {
+		t.Errorf("Function accepted a negative sample size")
+	}
+}
+
+func TestWeightedSampleWithoutReplacement(t *testing.T) {
+	weights := []float64{1, 0, 2, 1}
+	rng := NewRand(1)
+	firsts := make([]float64, len(weights))
+
+	for trial := 0; trial < 20000; trial++ {
+		sample, err := WeightedSampleWithoutReplacement(weights, 3, rng)
+
+		if err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+
+		sorted := append([]int(nil), sample...)
+		sort.Ints(sorted)
+		if !reflect.DeepEqual(sorted, []int{0, 2, 3}) {
+			t.Fatalf("Expected the indices with positive weights, got %v", sample)
+		}
+		firsts[sample[0]]++
+	}
+
+	// the first draw is proportional to the weights
+	if math.Abs(firsts[2]/20000-0.5) > 0.015 {
+		t.Errorf("Expected index 2 to be drawn first half of the time, got %v", firsts[2]/20000)
+	}
+
+	_, err := WeightedSampleWithoutReplacement(weights, 4, rng) // more than the positive weights
+
+	if err == nil {
+		t.Errorf("Function accepted a sample size larger than the number of positive weights")
+	}
+}
+
+func TestShuffle(t *testing.T) {
+	vector := []float64{1, 2, 3, 4, 5, 6, 7, 8}
+	other := copyVector(vector)
+
+	Shuffle(vector, NewRand(3))
+	Shuffle(other, NewRand(3))
+
+	if !reflect.DeepEqual(vector, other) {
+		t.Errorf("Expected equal shuffles from equal seeds, got %v and %v", vector, other)
+	}
+
+	sorted := copyVector(vector)
+	sort.Float64s(sorted)
+	if !reflect.DeepEqual(sorted, []float64{1, 2, 3, 4, 5, 6, 7, 8}) {
+		t.Errorf("Shuffle changed the elements, got %v", vector)
+	}
+
+	// every element is equally likely to end up first
+	rng := NewRand(4)
+	counts := make([]float64, 4)
+	for i := 0; i < 40000; i++ {
+		counts[Permutation(4, rng)[0]]++
+	}
+	for _, count := range counts {
+		if math.Abs(count/40000-0.25) > 0.01 {
+			t.Errorf("Expected each index first a quarter of the time, got %v", counts)
+			break
+		}
+	}
+}
+
+func TestReservoir(t *testing.T) {
+	// every value of the stream is equally likely to be kept
+	rng := NewRand(6)
+	counts := make([]float64, 10)
+	for trial := 0; trial < 20000; trial++ {
+		reservoir, _ := NewReservoir(3, rng)
+		for i := 0; i < 10; i++ {
+			reservoir.Add(float64(i))
+		}
+		for _, value := range reservoir.Sample() {
+			counts[int(value)]++
+		}
+	}
+	for _, count := range counts {
+		if math.Abs(count/20000-0.3) > 0.015 {
+			t.Errorf("Expected each value kept 30%% of the time, got %v", counts)
+			break
+		}
+	}
+
+	// a short stream is kept entirely
+	reservoir, _ := NewReservoir(5, nil)
+	reservoir.Add(1)
+	reservoir.Add(2)
+
+	if result := reservoir.Sample(); !reflect.DeepEqual(result, []float64{1, 2}) || reservoir.Count() != 2 {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", []float64{1, 2}, result)
+	}
+
+	_, err := NewReservoir(0, nil) // test the size logic
+
+	if err == nil {
+		t.Errorf("Function accepted a reservoir size of 0")
+	}
+}
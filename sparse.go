@@ -0,0 +1,228 @@
+package mlscratchlib
+
+import (
+	"errors"
+	"sort"
+)
+
+// COO is a sparse matrix in coordinate format, it stores a row index,
+// column index and value for every non-zero element. It is cheap to build
+// one element at a time, convert it to a CSR to do arithmetic with it
+type COO struct {
+	rows     int
+	cols     int
+	rowIndex []int
+	colIndex []int
+	values   []float64
+}
+
+// CSR is a sparse matrix in compressed sparse row format. The column
+// indexes and values of row i are stored in indices[indptr[i]:indptr[i+1]]
+// and values[indptr[i]:indptr[i+1]], sorted by column
+type CSR struct {
+	rows    int
+	cols    int
+	indptr  []int
+	indices []int
+	values  []float64
+}
+
+// NewCOO accepts a number of rows and columns and returns an empty
+// sparse matrix of that shape
+func NewCOO(rows int, columns int) *COO {
+	if rows < 0 || columns < 0 {
+		panic("matrix dimensions must not be negative")
+	}
+	return &COO{rows: rows, cols: columns}
+}
+
+// Add adds value to the element in row i and column j. Adding to the
+// same element more than once sums the values
+func (c *COO) Add(i int, j int, value float64) {
+	if i < 0 || i >= c.rows || j < 0 || j >= c.cols {
+		panic("matrix index out of range")
+	}
+	if value == 0 {
+		return
+	}
+	c.rowIndex = append(c.rowIndex, i)
+	c.colIndex = append(c.colIndex, j)
+	c.values = append(c.values, value)
+}
+
+// ToCSR returns the matrix in compressed sparse row format. Duplicate
+// entries are summed and any that sum to zero are dropped
+func (c *COO) ToCSR() *CSR {
+	// count the entries in each row then turn the counts into offsets
+	indptr := make([]int, c.rows+1)
+	for _, i := range c.rowIndex {
+		indptr[i+1]++
+	}
+	for i := 0; i < c.rows; i++ {
+		indptr[i+1] += indptr[i]
+	}
+
+	indices := make([]int, len(c.values))
+	values := make([]float64, len(c.values))
+	next := make([]int, c.rows)
+	copy(next, indptr[:c.rows])
+	for k, i := range c.rowIndex {
+		indices[next[i]] = c.colIndex[k]
+		values[next[i]] = c.values[k]
+		next[i]++
+	}
+
+	s := &CSR{rows: c.rows, cols: c.cols, indptr: make([]int, c.rows+1)}
+	for i := 0; i < c.rows; i++ {
+		rowIndices := indices[indptr[i]:indptr[i+1]]
+		rowValues := values[indptr[i]:indptr[i+1]]
+		sort.Sort(sparseRow{rowIndices, rowValues})
+
+		for k := 0; k < len(rowIndices); {
+			column, sum := rowIndices[k], 0.0
+			for ; k < len(rowIndices) && rowIndices[k] == column; k++ {
+				sum += rowValues[k]
+			}
+			if sum != 0 {
+				s.indices = append(s.indices, column)
+				s.values = append(s.values, sum)
+			}
+		}
+		s.indptr[i+1] = len(s.values)
+	}
+	return s
+}
+
+// sparseRow sorts the column indexes of a row and keeps the values in step
+type sparseRow struct {
+	indices []int
+	values  []float64
+}
+
+func (r sparseRow) Len() int           { return len(r.indices) }
+func (r sparseRow) Less(i, j int) bool { return r.indices[i] < r.indices[j] }
+func (r sparseRow) Swap(i, j int) {
+	r.indices[i], r.indices[j] = r.indices[j], r.indices[i]
+	r.values[i], r.values[j] = r.values[j], r.values[i]
+}
+
+// NewCSRFromDense accepts a matrix in the [][]float64 form used by
+// CreateMatrix and returns it as a sparse matrix, keeping only the
+// non-zero elements
+func NewCSRFromDense(matrix [][]float64) (*CSR, error) {
+	rows, columns := Shape(matrix)
+	s := &CSR{rows: rows, cols: columns, indptr: make([]int, rows+1)}
+	for i, row := range matrix {
+		if len(row) != columns {
+			return nil, errors.New("every row of the matrix must have the same number of elements")
+		}
+		for j, element := range row {
+			if element != 0 {
+				s.indices = append(s.indices, j)
+				s.values = append(s.values, element)
+			}
+		}
+		s.indptr[i+1] = len(s.values)
+	}
+	return s, nil
+}
+
+// Shape returns the number of rows and columns in the matrix
+func (s *CSR) Shape() (rows int, columns int) {
+	return s.rows, s.cols
+}
+
+// NonZero returns the number of stored non-zero elements
+func (s *CSR) NonZero() int {
+	return len(s.values)
+}
+
+// At returns the element in row i and column j
+func (s *CSR) At(i int, j int) float64 {
+	if i < 0 || i >= s.rows || j < 0 || j >= s.cols {
+		panic("matrix index out of range")
+	}
+	rowIndices := s.indices[s.indptr[i]:s.indptr[i+1]]
+	k := sort.SearchInts(rowIndices, j)
+	if k < len(rowIndices) && rowIndices[k] == j {
+		return s.values[s.indptr[i]+k]
+	}
+	return 0
+}
+
+// ToDense returns the matrix in the [][]float64 form used by CreateMatrix
+func (s *CSR) ToDense() [][]float64 {
+	matrix := make([][]float64, s.rows)
+	for i := range matrix {
+		matrix[i] = make([]float64, s.cols)
+		for k := s.indptr[i]; k < s.indptr[i+1]; k++ {
+			matrix[i][s.indices[k]] = s.values[k]
+		}
+	}
+	return matrix
+}
+
+// Transpose returns a new sparse matrix whose rows are the columns of s
+func (s *CSR) Transpose() *CSR {
+	t := &CSR{
+		rows:    s.cols,
+		cols:    s.rows,
+		indptr:  make([]int, s.cols+1),
+		indices: make([]int, len(s.indices)),
+		values:  make([]float64, len(s.values)),
+	}
+	for _, j := range s.indices {
+		t.indptr[j+1]++
+	}
+	for j := 0; j < s.cols; j++ {
+		t.indptr[j+1] += t.indptr[j]
+	}
+
+	// walking the rows in order leaves each row of t sorted by column
+	next := make([]int, s.cols)
+	copy(next, t.indptr[:s.cols])
+	for i := 0; i < s.rows; i++ {
+		for k := s.indptr[i]; k < s.indptr[i+1]; k++ {
+			j := s.indices[k]
+			t.indices[next[j]] = i
+			t.values[next[j]] = s.values[k]
+			next[j]++
+		}
+	}
+	return t
+}
+
+// MulVector returns the dense vector that results from multiplying the
+// sparse matrix by the dense column vector v
+func (s *CSR) MulVector(v []float64) ([]float64, error) {
+	if s.cols != len(v) {
+		return nil, errors.New("the vector must have one element for every column of the matrix")
+	}
+	vector := make([]float64, s.rows)
+	for i := range vector {
+		for k := s.indptr[i]; k < s.indptr[i+1]; k++ {
+			vector[i] += s.values[k] * v[s.indices[k]]
+		}
+	}
+	return vector, nil
+}
+
+// MulMatrix returns the dense matrix product of the sparse matrix and
+// the dense matrix b
+func (s *CSR) MulMatrix(b *Matrix) (*Matrix, error) {
+	if s.cols != b.rows {
+		return nil, errors.New("the number of columns in the first matrix must match the number of rows in the second")
+	}
+	product := NewMatrix(s.rows, b.cols)
+	for i := 0; i < s.rows; i++ {
+		productRow := product.data[i*b.cols : (i+1)*b.cols]
+		for k := s.indptr[i]; k < s.indptr[i+1]; k++ {
+			element := s.values[k]
+			bRow := b.data[s.indices[k]*b.cols : (s.indices[k]+1)*b.cols]
+			for j, bElement := range bRow {
+				productRow[j] += element * bElement
+			}
+		}
+	}
+	return product, nil
+}
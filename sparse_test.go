@@ -0,0 +1,121 @@
+package mlscratchlib
+
+import (
+	"reflect"
+	"testing"
+)
+
+// sample mostly zero matrix for testing sparse matrices
+var sparse3x4 = [][]float64{{0, 2, 0, 0}, {1, 0, 0, 3}, {0, 0, 0, 0}}
+
+func TestCOOToCSR(t *testing.T) {
+	c := NewCOO(3, 4)
+	c.Add(1, 3, 3)
+	c.Add(0, 1, 2)
+	c.Add(1, 0, 4)
+	c.Add(1, 0, -3) // duplicates are summed
+	c.Add(2, 2, 5)
+	c.Add(2, 2, -5) // entries that sum to zero are dropped
+
+	s := c.ToCSR()
+
+	if !reflect.DeepEqual(s.ToDense(), sparse3x4) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", sparse3x4, s.ToDense())
+	}
+
+	if s.NonZero() != 3 {
+		t.Errorf("Expected 3 non-zero elements, got %d", s.NonZero())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Add accepted an index out of range")
+		}
+	}()
+	c.Add(3, 0, 1)
+}
+
+func TestNewCSRFromDense(t *testing.T) {
+	s, err := NewCSRFromDense(sparse3x4)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	rows, columns := s.Shape()
+	if rows != 3 || columns != 4 || s.NonZero() != 3 {
+		t.Errorf("Expected a 3 x 4 matrix with 3 non-zero elements, got %d x %d with %d", rows, columns, s.NonZero())
+	}
+
+	if s.At(1, 3) != 3 || s.At(2, 1) != 0 {
+		t.Errorf("Expected 3 and 0, got %v and %v", s.At(1, 3), s.At(2, 1))
+	}
+
+	if !reflect.DeepEqual(s.ToDense(), sparse3x4) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", sparse3x4, s.ToDense())
+	}
+
+	s, err = NewCSRFromDense([][]float64{{1, 2}, {3}}) // test for ragged rows
+
+	if err == nil || s != nil {
+		t.Errorf("Function accepted ragged rows")
+	}
+}
+
+func TestCSRTranspose(t *testing.T) {
+	s, _ := NewCSRFromDense(sparse3x4)
+
+	result := s.Transpose().ToDense()
+	expected := mustMatrix(t, sparse3x4).Transpose().ToSlices()
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+}
+
+func TestCSRMulVector(t *testing.T) {
+	var err error
+	var expected, result []float64
+
+	s, _ := NewCSRFromDense(sparse3x4)
+	v := []float64{1, 2, 3, 4}
+
+	result, err = s.MulVector(v)
+	expected, _ = mustMatrix(t, sparse3x4).MulVector(v)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	result, err = s.MulVector(vec8a) // test the length mismatch logic
+
+	if err == nil || result != nil {
+		t.Errorf("Function accepted a vector of the wrong length, got %v", result)
+	}
+}
+
+func TestCSRMulMatrix(t *testing.T) {
+	s, _ := NewCSRFromDense(sparse3x4)
+	b := NewMatrixFromFunction(4, 2, func(i int, j int) float64 { return float64(i + 2*j) })
+
+	result, err := s.MulMatrix(b)
+	expected, _ := mustMatrix(t, sparse3x4).Mul(b)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !reflect.DeepEqual(result.ToSlices(), expected.ToSlices()) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected.ToSlices(), result.ToSlices())
+	}
+
+	result, err = s.MulMatrix(b.Transpose()) // test the shape mismatch logic
+
+	if err == nil || result != nil {
+		t.Errorf("Function accepted matrices with mismatched shapes")
+	}
+}
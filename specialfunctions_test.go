@@ -0,0 +1,100 @@
+package mlscratchlib
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRegularizedIncompleteBeta(t *testing.T) {
+	var expected, result float64
+
+	// for integer shapes I_x(a, b) is a binomial tail probability, here
+	// P(at least 2 successes in 4 trials with p = 0.4)
+	result = regularizedIncompleteBeta(2, 3, 0.4)
+	expected = 0.5248
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	// a beta(1, 1) distribution is uniform
+	result = regularizedIncompleteBeta(1, 1, 0.3)
+	expected = 0.3
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	// I_x(a, b) = 1 - I_(1-x)(b, a)
+	result = regularizedIncompleteBeta(2.5, 7, 0.2) + regularizedIncompleteBeta(7, 2.5, 0.8)
+	expected = 1
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+}
+
+func TestRegularizedLowerIncompleteGamma(t *testing.T) {
+	var expected, result float64
+
+	// a shape of 1 is the exponential distribution
+	result = regularizedLowerIncompleteGamma(1, 0.7)
+	expected = 1 - math.Exp(-0.7)
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	// for integer shapes P(a, x) is a Poisson tail probability, so both the
+	// series (x < a + 1) and the continued fraction (x >= a + 1) can be
+	// checked against a sum
+	for _, x := range []float64{2, 9} {
+		result = regularizedLowerIncompleteGamma(3, x)
+		expected = 1 - math.Exp(-x)*(1+x+x*x/2)
+
+		if !almostEqual(result, expected, 1e-12) {
+			t.Errorf("x = %v\nExpected result of:\n%v\ngot result:\n%v", x, expected, result)
+		}
+	}
+
+	// P(1/2, x) is erf(sqrt(x))
+	result = regularizedLowerIncompleteGamma(0.5, 2)
+	expected = math.Erf(math.Sqrt(2))
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+}
+
+func TestDigammaTrigamma(t *testing.T) {
+	const eulerGamma = 0.5772156649015329
+	var expected, result float64
+
+	result = digamma(1)
+	expected = -eulerGamma
+
+	if !almostEqual(result, expected, 1e-13) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	result = digamma(0.5)
+	expected = -eulerGamma - 2*math.Ln2
+
+	if !almostEqual(result, expected, 1e-13) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	result = trigamma(1)
+	expected = math.Pi * math.Pi / 6
+
+	if !almostEqual(result, expected, 1e-13) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	result = trigamma(0.5)
+	expected = math.Pi * math.Pi / 2
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+}
@@ -0,0 +1,218 @@
+package mlscratchlib
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// SVD holds the thin singular value decomposition of an m x n matrix A
+// so that A = U * diag(values) * V^T, where k = min(m, n), U is m x k,
+// V is n x k and the singular values are sorted from largest to smallest.
+// Columns that belong to a zero singular value are left as zeros in U
+// when m >= n, and in V when m < n, because a wide matrix is decomposed
+// through its transpose with the roles of U and V swapped
+type SVD struct {
+	u      *Matrix
+	values []float64
+	v      *Matrix
+}
+
+// SVDecompose accepts any matrix and returns its thin singular value
+// decomposition computed with the one-sided Jacobi method, which rotates
+// pairs of columns until every column is orthogonal to every other
+func SVDecompose(a *Matrix) (*SVD, error) {
+	if a.rows < a.cols {
+		// work on the tall transpose and swap the roles of U and V
+		f, err := SVDecompose(a.Transpose())
+		if err != nil {
+			return nil, err
+		}
+		return &SVD{u: f.v, values: f.values, v: f.u}, nil
+	}
+
+	m, n := a.rows, a.cols
+	u := a.Copy()
+	v := Identity(n)
+
+	const maxSweeps = 100
+	converged := false
+	for sweep := 0; sweep < maxSweeps && !converged; sweep++ {
+		converged = true
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				var alpha, beta, gamma float64
+				for i := 0; i < m; i++ {
+					alpha += u.data[i*n+p] * u.data[i*n+p]
+					beta += u.data[i*n+q] * u.data[i*n+q]
+					gamma += u.data[i*n+p] * u.data[i*n+q]
+				}
+				if gamma == 0 || math.Abs(gamma) <= machineEpsilon*math.Sqrt(alpha*beta) {
+					continue
+				}
+				converged = false
+
+				zeta := (beta - alpha) / (2 * gamma)
+				t := 1 / (math.Abs(zeta) + math.Sqrt(1+zeta*zeta))
+				if zeta < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(1+t*t)
+				s := c * t
+
+				for i := 0; i < m; i++ {
+					up, uq := u.data[i*n+p], u.data[i*n+q]
+					u.data[i*n+p] = c*up - s*uq
+					u.data[i*n+q] = s*up + c*uq
+				}
+				for i := 0; i < n; i++ {
+					vp, vq := v.data[i*n+p], v.data[i*n+q]
+					v.data[i*n+p] = c*vp - s*vq
+					v.data[i*n+q] = s*vp + c*vq
+				}
+			}
+		}
+	}
+	if !converged {
+		return nil, errors.New("the singular values did not converge")
+	}
+
+	// the singular values are the lengths of the orthogonalized columns
+	unsorted := make([]float64, n)
+	for j := range unsorted {
+		unsorted[j], _ = Magnitude(u.Column(j))
+	}
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i int, j int) bool {
+		return unsorted[order[i]] > unsorted[order[j]]
+	})
+
+	f := &SVD{u: NewMatrix(m, n), values: make([]float64, n), v: NewMatrix(n, n)}
+	for k, index := range order {
+		sigma := unsorted[index]
+		f.values[k] = sigma
+		for i := 0; i < m; i++ {
+			if sigma != 0 {
+				f.u.data[i*n+k] = u.data[i*n+index] / sigma
+			}
+		}
+		for i := 0; i < n; i++ {
+			f.v.data[i*n+k] = v.data[i*n+index]
+		}
+	}
+	return f, nil
+}
+
+// U returns the m x k matrix of left singular vectors
+func (f *SVD) U() *Matrix {
+	return f.u.Copy()
+}
+
+// V returns the n x k matrix of right singular vectors
+func (f *SVD) V() *Matrix {
+	return f.v.Copy()
+}
+
+// Values returns the singular values sorted from largest to smallest
+func (f *SVD) Values() []float64 {
+	values := make([]float64, len(f.values))
+	copy(values, f.values)
+	return values
+}
+
+// cutoff returns the value at or below which a singular value is treated
+// as zero. A tolerance <= 0 selects the default of max(m, n) * machine
+// epsilon * the largest singular value
+func (f *SVD) cutoff(tolerance float64) float64 {
+	if tolerance > 0 {
+		return tolerance
+	}
+	if len(f.values) < 1 {
+		return 0
+	}
+	size := math.Max(float64(f.u.rows), float64(f.v.rows))
+	return size * machineEpsilon * f.values[0]
+}
+
+// Rank returns the number of singular values greater than the tolerance.
+// A tolerance <= 0 selects a default based on machine precision
+func (f *SVD) Rank(tolerance float64) int {
+	cutoff := f.cutoff(tolerance)
+	rank := 0
+	for _, value := range f.values {
+		if value > cutoff {
+			rank++
+		}
+	}
+	return rank
+}
+
+// PseudoInverse returns the n x m Moore-Penrose pseudo-inverse
+// V * diag(1/values) * U^T, where singular values at or below the
+// tolerance are treated as zero instead of being inverted
+func (f *SVD) PseudoInverse(tolerance float64) *Matrix {
+	cutoff := f.cutoff(tolerance)
+	m, n, k := f.u.rows, f.v.rows, len(f.values)
+	inverse := NewMatrix(n, m)
+	for l, value := range f.values {
+		if value <= cutoff {
+			continue
+		}
+		for i := 0; i < n; i++ {
+			scaled := f.v.data[i*k+l] / value
+			for j := 0; j < m; j++ {
+				inverse.data[i*m+j] += scaled * f.u.data[j*k+l]
+			}
+		}
+	}
+	return inverse
+}
+
+// ConditionNumber returns the ratio of the largest singular value to
+// the smallest. It returns +Inf when the smallest singular value is at
+// or below the tolerance, ie. when the matrix is rank deficient
+func (f *SVD) ConditionNumber(tolerance float64) float64 {
+	if len(f.values) < 1 {
+		return 0
+	}
+	smallest := f.values[len(f.values)-1]
+	if smallest <= f.cutoff(tolerance) {
+		return math.Inf(1)
+	}
+	return f.values[0] / smallest
+}
+
+// Rank accepts a matrix and returns the number of its singular values
+// greater than the tolerance. A tolerance <= 0 selects a default based
+// on machine precision
+func Rank(a *Matrix, tolerance float64) (int, error) {
+	f, err := SVDecompose(a)
+	if err != nil {
+		return 0, err
+	}
+	return f.Rank(tolerance), nil
+}
+
+// PseudoInverse accepts a matrix and returns its Moore-Penrose
+// pseudo-inverse, treating singular values at or below the tolerance
+// as zero
+func PseudoInverse(a *Matrix, tolerance float64) (*Matrix, error) {
+	f, err := SVDecompose(a)
+	if err != nil {
+		return nil, err
+	}
+	return f.PseudoInverse(tolerance), nil
+}
+
+// ConditionNumber accepts a matrix and returns the ratio of its largest
+// singular value to its smallest, or +Inf if it is rank deficient
+func ConditionNumber(a *Matrix, tolerance float64) (float64, error) {
+	f, err := SVDecompose(a)
+	if err != nil {
+		return 0, err
+	}
+	return f.ConditionNumber(tolerance), nil
+}
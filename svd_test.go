@@ -0,0 +1,139 @@
+package mlscratchlib
+
+import (
+	"math"
+	"testing"
+)
+
+// rebuildSVD multiplies the factors back together as U * diag(values) * V^T
+func rebuildSVD(f *SVD) *Matrix {
+	u := f.U()
+	for i := 0; i < u.rows; i++ {
+		for k, value := range f.Values() {
+			u.Set(i, k, u.At(i, k)*value)
+		}
+	}
+	result, _ := u.Mul(f.V().Transpose())
+	return result
+}
+
+func TestSVDecompose(t *testing.T) {
+	for _, matrix := range [][][]float64{design4x2, matrix2x3, square3a, singular3a} {
+		a := mustMatrix(t, matrix)
+
+		f, err := SVDecompose(a)
+
+		if err != nil {
+			t.Errorf("Error: %v", err)
+		}
+
+		result := rebuildSVD(f)
+
+		if !matricesAlmostEqual(result, a, 1e-10) {
+			t.Errorf("Expected result of:\n%v\ngot result:\n%v", matrix, result.ToSlices())
+		}
+
+		values := f.Values()
+		for i := 1; i < len(values); i++ {
+			if values[i] > values[i-1] {
+				t.Errorf("Singular values are not sorted descending: %v", values)
+			}
+		}
+	}
+
+	// the singular values of a diagonal matrix are the absolute diagonal
+	f, _ := SVDecompose(mustMatrix(t, [][]float64{{-2, 0}, {0, 3}}))
+	expected := []float64{3, 2}
+
+	if !vectorsAlmostEqual(f.Values(), expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, f.Values())
+	}
+}
+
+func TestRank(t *testing.T) {
+	var err error
+	var expected, result int
+
+	result, err = Rank(mustMatrix(t, square3a), 0)
+	expected = 3
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if result != expected {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	result, err = Rank(mustMatrix(t, singular3a), 0)
+	expected = 2
+
+	if result != expected {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	// a large tolerance throws away the smaller singular values too
+	result, err = Rank(mustMatrix(t, singular3a), 10)
+	expected = 1
+
+	if result != expected {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+}
+
+func TestPseudoInverse(t *testing.T) {
+	// the pseudo-inverse of an invertible matrix is its inverse
+	a := mustMatrix(t, square3a)
+	result, err := PseudoInverse(a, 0)
+	expected, _ := Inverse(a)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !matricesAlmostEqual(result, expected, 1e-10) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected.ToSlices(), result.ToSlices())
+	}
+
+	// A * A+ * A = A holds even for rank deficient matrices
+	a = mustMatrix(t, singular3a)
+	result, err = PseudoInverse(a, 0)
+	aa, _ := a.Mul(result)
+	aaa, _ := aa.Mul(a)
+
+	if !matricesAlmostEqual(aaa, a, 1e-10) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", singular3a, aaa.ToSlices())
+	}
+
+	// the pseudo-inverse of a tall matrix gives the least squares solution
+	a = mustMatrix(t, design4x2)
+	result, err = PseudoInverse(a, 0)
+	coefficients, _ := result.MulVector([]float64{1, 3, 2, 5})
+	expectedCoefficients := []float64{1.1, 1.1}
+
+	if !vectorsAlmostEqual(coefficients, expectedCoefficients, 1e-10) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expectedCoefficients, coefficients)
+	}
+}
+
+func TestConditionNumber(t *testing.T) {
+	var err error
+	var expected, result float64
+
+	result, err = ConditionNumber(mustMatrix(t, [][]float64{{-2, 0}, {0, 8}}), 0)
+	expected = 4
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	result, err = ConditionNumber(mustMatrix(t, singular3a), 0)
+
+	if !math.IsInf(result, 1) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", math.Inf(1), result)
+	}
+}
@@ -0,0 +1,279 @@
+package mlscratchlib
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"sort"
+)
+
+// TDigest is a mergeable sketch of a distribution, built from a stream of
+// values, that answers approximate quantile and CDF queries in bounded
+// memory. It is the merging t-digest from Dunning and Ertl, "Computing
+// Extremely Accurate Quantiles Using t-Digests" (2019), with the k1
+// scale function.
+//
+// Memory: the digest keeps at most about compression centroids, plus a
+// buffer of up to 5*compression values that have not been merged yet.
+//
+// Error: the k1 scale function limits a centroid that sits at quantile q
+// to a fraction 2*pi*sqrt(q*(1-q))/compression of all the values, so
+// the rank error of Quantile and CDF is at most about
+// pi*sqrt(q*(1-q))/compression. That is about 1.6% of the count at the
+// median for the default compression of 100 and much smaller in the
+// tails, where the first and last values are always exact
+type TDigest struct {
+	compression float64
+	centroids   []centroid // merged centroids sorted by mean
+	buffer      []centroid // values added since the last merge
+	count       float64
+	min         float64
+	max         float64
+}
+
+// centroid is a cluster of values summarized by their mean and count
+type centroid struct {
+	mean   float64
+	weight float64
+}
+
+// DefaultTDigestCompression is a compression that gives roughly 1%
+// accuracy at the median with about 100 centroids
+const DefaultTDigestCompression = 100
+
+// tdigestEncodingVersion is written first by MarshalBinary so that the
+// format can change without misreading old data
+const tdigestEncodingVersion = 1
+
+// NewTDigest accepts a compression parameter and returns an empty digest.
+// Larger compressions are more accurate and use proportionally more memory
+func NewTDigest(compression float64) (*TDigest, error) {
+	if !(compression >= 1) || math.IsInf(compression, 1) {
+		return nil, errors.New("compression must be a number of at least 1")
+	}
+	return &TDigest{compression: compression}, nil
+}
+
+// Add adds a single value to the digest
+func (d *TDigest) Add(x float64) {
+	d.addCentroid(centroid{mean: x, weight: 1})
+}
+
+func (d *TDigest) addCentroid(c centroid) {
+	if d.count == 0 {
+		d.min, d.max = c.mean, c.mean
+	} else {
+		d.min = math.Min(d.min, c.mean)
+		d.max = math.Max(d.max, c.mean)
+	}
+	d.buffer = append(d.buffer, c)
+	d.count += c.weight
+	if float64(len(d.buffer)) >= 5*d.compression {
+		d.flush()
+	}
+}
+
+// Merge adds every value summarized by other to d. The compression of
+// d is kept. Other may be d itself, which doubles the weight of every
+// value
+func (d *TDigest) Merge(other *TDigest) {
+	if other.count == 0 {
+		return
+	}
+	// adding to d can flush it, which reuses the buffer, so take copies
+	// first in case other is d
+	centroids := append(append([]centroid(nil), other.centroids...), other.buffer...)
+	low, high := other.min, other.max
+	for _, c := range centroids {
+		d.addCentroid(c)
+	}
+	// the centroids of other may be heavier than single values, so keep
+	// the exact extremes rather than their means
+	d.min = math.Min(d.min, low)
+	d.max = math.Max(d.max, high)
+}
+
+// Count returns the number of values added
+func (d *TDigest) Count() int {
+	return int(d.count)
+}
+
+// Centroids returns the number of centroids the digest is keeping once
+// any buffered values have been merged
+func (d *TDigest) Centroids() int {
+	d.flush()
+	return len(d.centroids)
+}
+
+// scale is the k1 scale function, it maps a quantile to an index so
+// that neighbouring centroids may only be merged while their combined
+// span of index stays under 1
+func (d *TDigest) scale(q float64) float64 {
+	return d.compression / (2 * math.Pi) * math.Asin(2*q-1)
+}
+
+// flush merges the buffered values into the centroids
+func (d *TDigest) flush() {
+	if len(d.buffer) == 0 {
+		return
+	}
+	all := append(d.centroids, d.buffer...)
+	sort.Slice(all, func(i int, j int) bool { return all[i].mean < all[j].mean })
+
+	merged := make([]centroid, 0, len(d.centroids)+1)
+	current := all[0]
+	var weightSoFar float64
+	for _, c := range all[1:] {
+		q := (weightSoFar + current.weight + c.weight) / d.count
+		if d.scale(q)-d.scale(weightSoFar/d.count) <= 1 {
+			current.weight += c.weight
+			current.mean += (c.mean - current.mean) * c.weight / current.weight
+			continue
+		}
+		weightSoFar += current.weight
+		merged = append(merged, current)
+		current = c
+	}
+	d.centroids = append(merged, current)
+	d.buffer = d.buffer[:0]
+}
+
+// Quantile accepts a decimal between 0 and 1 and returns an estimate
+// of the value at that quantile of everything added to the digest
+func (d *TDigest) Quantile(p float64) (float64, error) {
+	if d.count == 0 {
+		return 0, errors.New("the digest is empty")
+	}
+	if p < 0 || p > 1 || math.IsNaN(p) {
+		return 0, errors.New("percentile must be a decimal between 0 and 1")
+	}
+	d.flush()
+	if p == 0 {
+		return d.min, nil
+	} else if p == 1 {
+		return d.max, nil
+	}
+
+	// each centroid is treated as sitting at the middle of its weight,
+	// and the quantile is interpolated between neighbouring centroids
+	target := p * d.count
+	first, last := d.centroids[0], d.centroids[len(d.centroids)-1]
+	if target < first.weight/2 {
+		return d.min + (first.mean-d.min)*target/(first.weight/2), nil
+	}
+	if target > d.count-last.weight/2 {
+		return last.mean + (d.max-last.mean)*(target-(d.count-last.weight/2))/(last.weight/2), nil
+	}
+
+	rank := first.weight / 2
+	for i := 0; i < len(d.centroids)-1; i++ {
+		left, right := d.centroids[i], d.centroids[i+1]
+		gap := (left.weight + right.weight) / 2
+		if target <= rank+gap {
+			return left.mean + (right.mean-left.mean)*(target-rank)/gap, nil
+		}
+		rank += gap
+	}
+	return last.mean, nil
+}
+
+// CDF accepts a value and returns an estimate of the fraction of the
+// values added to the digest that are less than or equal to it. It
+// returns NaN if the digest is empty
+func (d *TDigest) CDF(x float64) float64 {
+	if d.count == 0 {
+		return math.NaN()
+	}
+	d.flush()
+	if x < d.min {
+		return 0
+	} else if x >= d.max {
+		return 1
+	}
+
+	first, last := d.centroids[0], d.centroids[len(d.centroids)-1]
+	if x < first.mean {
+		return (x - d.min) / (first.mean - d.min) * first.weight / 2 / d.count
+	}
+	if x >= last.mean {
+		rank := d.count - last.weight/2 + (x-last.mean)/(d.max-last.mean)*last.weight/2
+		return rank / d.count
+	}
+
+	rank := first.weight / 2
+	for i := 0; i < len(d.centroids)-1; i++ {
+		left, right := d.centroids[i], d.centroids[i+1]
+		gap := (left.weight + right.weight) / 2
+		if x < right.mean {
+			return (rank + (x-left.mean)/(right.mean-left.mean)*gap) / d.count
+		}
+		rank += gap
+	}
+	return 1
+}
+
+// MarshalBinary encodes the digest so that it can be stored or sent to
+// another process and read back with UnmarshalBinary
+func (d *TDigest) MarshalBinary() ([]byte, error) {
+	d.flush()
+	var buf bytes.Buffer
+	values := []interface{}{
+		uint8(tdigestEncodingVersion), d.compression, d.count, d.min, d.max, uint32(len(d.centroids)),
+	}
+	for _, c := range d.centroids {
+		values = append(values, c.mean, c.weight)
+	}
+	for _, value := range values {
+		if err := binary.Write(&buf, binary.LittleEndian, value); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary replaces the digest with one decoded from data
+// produced by MarshalBinary
+func (d *TDigest) UnmarshalBinary(data []byte) error {
+	r := bytes.NewReader(data)
+	var version uint8
+	var decoded TDigest
+	var size uint32
+	for _, value := range []interface{}{&version, &decoded.compression, &decoded.count, &decoded.min, &decoded.max, &size} {
+		if err := binary.Read(r, binary.LittleEndian, value); err != nil {
+			return errors.New("the data is too short to be a t-digest")
+		}
+	}
+	if version != tdigestEncodingVersion {
+		return errors.New("unknown t-digest encoding version")
+	}
+	if !(decoded.compression >= 1) || math.IsInf(decoded.compression, 1) || uint64(r.Len()) != 16*uint64(size) {
+		return errors.New("the data is not a valid t-digest")
+	}
+	if !(decoded.count >= 0) || math.IsInf(decoded.count, 1) || (size == 0) != (decoded.count == 0) {
+		return errors.New("the data has an invalid t-digest count")
+	}
+	if size > 0 && !(decoded.min <= decoded.max) {
+		return errors.New("the data has an invalid t-digest range")
+	}
+	decoded.centroids = make([]centroid, size)
+	var total float64
+	for i := range decoded.centroids {
+		c := &decoded.centroids[i]
+		if err := binary.Read(r, binary.LittleEndian, &c.mean); err != nil {
+			return errors.New("the data is too short to be a t-digest")
+		}
+		if err := binary.Read(r, binary.LittleEndian, &c.weight); err != nil {
+			return errors.New("the data is too short to be a t-digest")
+		}
+		if math.IsNaN(c.mean) || !(c.weight > 0) || math.IsInf(c.weight, 1) {
+			return errors.New("the data has an invalid t-digest centroid")
+		}
+		total += c.weight
+	}
+	if total != decoded.count {
+		return errors.New("the centroid weights don't add up to the t-digest count")
+	}
+	*d = decoded
+	return nil
+}
@@ -0,0 +1,222 @@
+package mlscratchlib
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// digestOf returns a digest of the given values and fails the test if
+// the digest can't be created
+func digestOf(t *testing.T, values []float64) *TDigest {
+	t.Helper()
+	d, err := NewTDigest(DefaultTDigestCompression)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	for _, value := range values {
+		d.Add(value)
+	}
+	return d
+}
+
+// exponentialSample returns n seeded draws from an exponential distribution
+// so the tests see a skewed, long tailed stream
+func exponentialSample(n int, seed int64) []float64 {
+	rng := rand.New(rand.NewSource(seed))
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = rng.ExpFloat64()
+	}
+	return values
+}
+
+func TestNewTDigest(t *testing.T) {
+	d, err := NewTDigest(0.5) // test the compression range logic
+
+	if err == nil || d != nil {
+		t.Errorf("Function accepted a compression below 1")
+	}
+
+	d, _ = NewTDigest(DefaultTDigestCompression)
+	_, err = d.Quantile(0.5) // test for an empty digest
+
+	if err == nil {
+		t.Errorf("Function returned a quantile for an empty digest")
+	}
+
+	if !math.IsNaN(d.CDF(0)) {
+		t.Errorf("Expected NaN for the CDF of an empty digest, got %v", d.CDF(0))
+	}
+}
+
+func TestTDigestQuantile(t *testing.T) {
+	values := exponentialSample(100000, 1)
+	d := digestOf(t, values)
+
+	if d.Count() != len(values) {
+		t.Errorf("Expected count of %d, got %d", len(values), d.Count())
+	}
+
+	if d.Centroids() > DefaultTDigestCompression {
+		t.Errorf("Expected at most %d centroids, got %d", DefaultTDigestCompression, d.Centroids())
+	}
+
+	for _, p := range []float64{0, 0.001, 0.01, 0.1, 0.25, 0.5, 0.75, 0.9, 0.99, 0.999, 1} {
+		result, err := d.Quantile(p)
+
+		if err != nil {
+			t.Errorf("Error: %v", err)
+		}
+
+		// check the documented rank error bound by seeing where the
+		// estimate falls among the exact values
+		var below float64
+		for _, value := range values {
+			if value < result {
+				below++
+			}
+		}
+		rankError := math.Abs(below/float64(len(values)) - p)
+		bound := math.Pi*math.Sqrt(p*(1-p))/DefaultTDigestCompression + 1.0/float64(len(values))
+
+		if rankError > bound {
+			t.Errorf("p = %v rank error %v exceeds bound %v", p, rankError, bound)
+		}
+	}
+
+	_, err := d.Quantile(1.5) // test the percentile range logic
+
+	if err == nil {
+		t.Errorf("Function accepted a percentile greater than 1")
+	}
+}
+
+func TestTDigestCDF(t *testing.T) {
+	values := exponentialSample(50000, 2)
+	d := digestOf(t, values)
+
+	for _, x := range []float64{0.01, 0.1, 0.5, 1, 2, 5} {
+		result := d.CDF(x)
+		expected := 1 - math.Exp(-x) // the exponential CDF
+
+		if !almostEqual(result, expected, 0.01) {
+			t.Errorf("x = %v\nExpected result of:\n%v\ngot result:\n%v", x, expected, result)
+		}
+	}
+
+	if d.CDF(-1) != 0 || d.CDF(math.Inf(1)) != 1 {
+		t.Errorf("Expected 0 and 1 outside the range of the data, got %v and %v", d.CDF(-1), d.CDF(math.Inf(1)))
+	}
+}
+
+func TestTDigestMerge(t *testing.T) {
+	values := exponentialSample(40000, 3)
+	whole := digestOf(t, values)
+
+	merged := digestOf(t, nil)
+	for i := 0; i < 4; i++ {
+		merged.Merge(digestOf(t, values[i*10000:(i+1)*10000]))
+	}
+
+	if merged.Count() != whole.Count() {
+		t.Errorf("Expected count of %d, got %d", whole.Count(), merged.Count())
+	}
+
+	for _, p := range []float64{0, 0.01, 0.5, 0.99, 1} {
+		result, _ := merged.Quantile(p)
+		expected, _ := whole.Quantile(p)
+
+		if !almostEqual(result, expected, 0.02*math.Max(1, expected)) {
+			t.Errorf("p = %v\nExpected result of:\n%v\ngot result:\n%v", p, expected, result)
+		}
+	}
+
+	// merging a digest with itself doubles every value, with unmerged
+	// values in the buffer so that the merge flushes part way through
+	self := digestOf(t, values[:10000])
+	for _, x := range values[10000:10300] {
+		self.Add(x)
+	}
+	self.Quantile(0.5) // merge the buffer once so the next values start a new one
+	for _, x := range values[10300:10400] {
+		self.Add(x)
+	}
+	reference := digestOf(t, values[:10400])
+	reference.Merge(digestOf(t, values[:10400]))
+	self.Merge(self)
+
+	if self.Count() != 20800 {
+		t.Errorf("Expected count of %d, got %d", 20800, self.Count())
+	}
+
+	for _, p := range []float64{0, 0.1, 0.5, 0.9, 1} {
+		result, _ := self.Quantile(p)
+		expected, _ := reference.Quantile(p)
+
+		if !almostEqual(result, expected, 0.02*math.Max(1, expected)) {
+			t.Errorf("p = %v\nExpected result of:\n%v\ngot result:\n%v", p, expected, result)
+		}
+	}
+}
+
+func TestTDigestMarshalBinary(t *testing.T) {
+	d := digestOf(t, exponentialSample(10000, 4))
+
+	data, err := d.MarshalBinary()
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	var decoded TDigest
+	err = decoded.UnmarshalBinary(data)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	for _, p := range []float64{0, 0.1, 0.5, 0.9, 1} {
+		result, _ := decoded.Quantile(p)
+		expected, _ := d.Quantile(p)
+
+		if result != expected {
+			t.Errorf("p = %v\nExpected result of:\n%v\ngot result:\n%v", p, expected, result)
+		}
+	}
+
+	// the decoded digest keeps accepting values
+	decoded.Add(-1)
+	if result, _ := decoded.Quantile(0); result != -1 {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", -1, result)
+	}
+
+	for _, length := range []int{len(data) - 3, len(data) - 16, 20, 0} { // test for truncated data
+		if err = decoded.UnmarshalBinary(data[:length]); err == nil {
+			t.Errorf("Function accepted data truncated to %d of %d bytes", length, len(data))
+		}
+	}
+
+	// the header is the version byte, then the compression, count, min
+	// and max as float64s and the number of centroids as a uint32
+	corrupt := func(offset int, value interface{}) []byte {
+		var buf bytes.Buffer
+		binary.Write(&buf, binary.LittleEndian, value)
+		changed := append([]byte(nil), data...)
+		copy(changed[offset:], buf.Bytes())
+		return changed
+	}
+	for name, changed := range map[string][]byte{
+		"a negative count":            corrupt(9, -1.0),
+		"a count that doesn't add up": corrupt(9, d.count+1),
+		"an oversized centroid count": corrupt(33, uint32(1<<31)),
+		"a NaN mean":                  corrupt(37, math.NaN()),
+		"a NaN weight":                corrupt(45, math.NaN()),
+	} {
+		if err = decoded.UnmarshalBinary(changed); err == nil {
+			t.Errorf("Function accepted %v", name)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package mlscratchlib
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// WeightType selects what the weights passed to the weighted statistics
+// mean, which changes the denominator of the variance
+type WeightType int
+
+const (
+	// FrequencyWeights count how many times each value was observed, the
+	// variance divides by sum(w) - 1 so that integer weights give the same
+	// answer as VarianceVector on the expanded data
+	FrequencyWeights WeightType = iota
+	// ReliabilityWeights describe how much each value should be trusted,
+	// such as survey sampling weights, the variance divides by
+	// sum(w) - sum(w^2)/sum(w) to stay unbiased whatever their scale
+	ReliabilityWeights
+)
+
+// checkWeights validates a weights vector against the length of the data
+// and returns the sum of the weights
+func checkWeights(length int, weights []float64) (float64, error) {
+	if length != len(weights) {
+		return 0, errors.New("the vector and the weights must be the same length")
+	} else if length < 1 {
+		return 0, errors.New("something went wrong vector length is 0")
+	}
+	var sum float64
+	for _, weight := range weights {
+		if weight < 0 || math.IsNaN(weight) {
+			return 0, errors.New("weights must not be negative")
+		}
+		sum += weight
+	}
+	if sum == 0 {
+		return 0, errors.New("the weights must not all be 0")
+	}
+	return sum, nil
+}
+
+// varianceDivisor returns the number to divide a weighted sum of squared
+// deviations by for the given weight type
+func varianceDivisor(weights []float64, sum float64, weightType WeightType) (float64, error) {
+	var divisor float64
+	switch weightType {
+	case FrequencyWeights:
+		divisor = sum - 1
+	case ReliabilityWeights:
+		sumOfSquares, _ := SumofSquares(weights)
+		divisor = sum - sumOfSquares/sum
+	default:
+		return 0, errors.New("unknown weight type")
+	}
+	if divisor <= 0 {
+		return 0, errors.New("the weights are too small to estimate a variance")
+	}
+	return divisor, nil
+}
+
+// WeightedMean accepts a vector and a vector of weights and returns the
+// sum of each element times its weight divided by the sum of the weights
+func WeightedMean(vector []float64, weights []float64) (float64, error) {
+	sum, err := checkWeights(len(vector), weights)
+	if err != nil {
+		return 0, err
+	}
+	product, _ := DotProduct(vector, weights)
+	return product / sum, nil
+}
+
+// WeightedVariance accepts a vector, a vector of weights and a weight type
+// and returns the weighted variance of the vector
+func WeightedVariance(vector []float64, weights []float64, weightType WeightType) (float64, error) {
+	return WeightedCovariance(vector, vector, weights, weightType)
+}
+
+// WeightedStandardDeviation returns the square root of WeightedVariance
+func WeightedStandardDeviation(vector []float64, weights []float64, weightType WeightType) (float64, error) {
+	variance, err := WeightedVariance(vector, weights, weightType)
+	if err != nil {
+		return 0, err
+	}
+	return math.Sqrt(variance), nil
+}
+
+// WeightedCovariance accepts two vectors, a vector of weights and a weight
+// type and returns the weighted covariance of the two vectors
+func WeightedCovariance(a []float64, b []float64, weights []float64, weightType WeightType) (float64, error) {
+	if len(a) != len(b) {
+		return 0, errors.New("vectors must be the same length")
+	}
+	sum, err := checkWeights(len(a), weights)
+	if err != nil {
+		return 0, err
+	}
+	divisor, err := varianceDivisor(weights, sum, weightType)
+	if err != nil {
+		return 0, err
+	}
+	aMean, _ := WeightedMean(a, weights)
+	bMean, _ := WeightedMean(b, weights)
+
+	var deviations float64
+	for i, weight := range weights {
+		deviations += weight * (a[i] - aMean) * (b[i] - bMean)
+	}
+	return deviations / divisor, nil
+}
+
+// WeightedCorrelation accepts two vectors and a vector of weights and
+// returns the weighted Pearson correlation of the two vectors. The weight
+// type cancels out so it isn't needed. It returns an error if either
+// vector has a weighted standard deviation of 0
+func WeightedCorrelation(a []float64, b []float64, weights []float64) (float64, error) {
+	covariance, err := WeightedCovariance(a, b, weights, ReliabilityWeights)
+	if err != nil {
+		return 0, err
+	}
+	aVariance, _ := WeightedVariance(a, weights, ReliabilityWeights)
+	bVariance, _ := WeightedVariance(b, weights, ReliabilityWeights)
+	if aVariance <= 0 || bVariance <= 0 {
+		return 0, errors.New("correlation is undefined when a vector has a standard deviation of 0")
+	}
+	return covariance / math.Sqrt(aVariance) / math.Sqrt(bVariance), nil
+}
+
+// WeightedQuantile accepts a vector, a vector of weights and a decimal
+// between 0 and 1 and returns the smallest element whose cumulative
+// weight, counted from the smallest element up, reaches percentile times
+// the total weight. With integer frequency weights this matches
+// Quantile with QuantileType1 on the expanded data. The caller's vectors
+// are left in their original order
+func WeightedQuantile(vector []float64, weights []float64, percentile float64) (float64, error) {
+	sum, err := checkWeights(len(vector), weights)
+	if err != nil {
+		return 0, err
+	}
+	if percentile < 0 || percentile > 1 || math.IsNaN(percentile) {
+		return 0, errors.New("percentile must be a decimal between 0 and 1")
+	}
+
+	order := make([]int, len(vector))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i int, j int) bool { return vector[order[i]] < vector[order[j]] })
+
+	target := percentile * sum
+	var cumulative float64
+	for _, i := range order {
+		if weights[i] == 0 {
+			continue
+		}
+		cumulative += weights[i]
+		if cumulative >= target*(1-quantileFuzz) {
+			return vector[i], nil
+		}
+	}
+
+	// rounding left the cumulative weight a hair short, return the largest
+	for k := len(order) - 1; k >= 0; k-- {
+		if weights[order[k]] > 0 {
+			return vector[order[k]], nil
+		}
+	}
+	return 0, nil
+}
@@ -0,0 +1,164 @@
+package mlscratchlib
+
+import "testing"
+
+// sample data with integer frequency weights and the same data expanded
+// so that each value appears weight times
+var weightedValues = []float64{3, 1, 4, 1.5, 9, 2.5}
+var weightedCounts = []float64{2, 1, 3, 1, 1, 2}
+var weightedExpanded = []float64{3, 3, 1, 4, 4, 4, 1.5, 9, 2.5, 2.5}
+
+func TestWeightedMean(t *testing.T) {
+	result, err := WeightedMean(weightedValues, weightedCounts)
+	expected := VectorMean(weightedExpanded)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	result, err = WeightedMean(vec8a, vec10a) // test the length mismatch logic
+
+	if err == nil {
+		t.Errorf("Function accepted vectors of mismatched lengths")
+	}
+
+	result, err = WeightedMean([]float64{1, 2}, []float64{1, -1}) // test for negative weights
+
+	if err == nil {
+		t.Errorf("Function accepted a negative weight")
+	}
+
+	result, err = WeightedMean([]float64{1, 2}, []float64{0, 0}) // test for all zero weights
+
+	if err == nil {
+		t.Errorf("Function accepted weights that are all 0")
+	}
+}
+
+func TestWeightedVariance(t *testing.T) {
+	var err error
+	var expected, result float64
+
+	// frequency weights match the variance of the expanded data
+	result, err = WeightedVariance(weightedValues, weightedCounts, FrequencyWeights)
+	expected = VarianceVector(weightedExpanded)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	// equal reliability weights of any scale match the unweighted variance
+	result, err = WeightedVariance(vec8a, ScalarMultiply(0.3, []float64{1, 1, 1, 1, 1, 1, 1, 1}), ReliabilityWeights)
+	expected = VarianceVector(vec8a)
+
+	if !almostEqual(result, expected, 1e-9) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	// frequency weights summing to 1 or less can't estimate a variance
+	result, err = WeightedVariance([]float64{1, 2}, []float64{0.5, 0.5}, FrequencyWeights)
+
+	if err == nil {
+		t.Errorf("Function accepted frequency weights that sum to 1")
+	}
+
+	result, err = WeightedVariance(weightedValues, weightedCounts, WeightType(5)) // test for an unknown weight type
+
+	if err == nil {
+		t.Errorf("Function accepted an unknown weight type")
+	}
+
+	result, err = WeightedStandardDeviation(weightedValues, weightedCounts, FrequencyWeights)
+	expected = StandardDeviationVector(weightedExpanded)
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+}
+
+func TestWeightedCovariance(t *testing.T) {
+	var err error
+	var expected, result float64
+
+	other := []float64{2, 7, 1, 8, 2, 8}
+	otherExpanded := []float64{2, 2, 7, 1, 1, 1, 8, 2, 8, 8}
+
+	result, err = WeightedCovariance(weightedValues, other, weightedCounts, FrequencyWeights)
+	expected, _ = Covariance(weightedExpanded, otherExpanded)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	result, err = WeightedCovariance(vec8a, vec10a, vec8b, FrequencyWeights) // test the length mismatch logic
+
+	if err == nil {
+		t.Errorf("Function accepted vectors of mismatched lengths")
+	}
+}
+
+func TestWeightedCorrelation(t *testing.T) {
+	var err error
+	var expected, result float64
+
+	other := []float64{2, 7, 1, 8, 2, 8}
+	otherExpanded := []float64{2, 2, 7, 1, 1, 1, 8, 2, 8, 8}
+
+	result, err = WeightedCorrelation(weightedValues, other, weightedCounts)
+	expected, _ = Correlation(weightedExpanded, otherExpanded)
+
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if !almostEqual(result, expected, 1e-12) {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	result, err = WeightedCorrelation([]float64{1, 1, 1}, []float64{1, 2, 3}, []float64{1, 1, 1}) // test for zero variance
+
+	if err == nil {
+		t.Errorf("Function accepted a vector with a standard deviation of 0")
+	}
+}
+
+func TestWeightedQuantile(t *testing.T) {
+	for _, percentile := range []float64{0, 0.1, 0.25, 0.5, 0.7, 0.9, 1} {
+		result, err := WeightedQuantile(weightedValues, weightedCounts, percentile)
+		expected, _ := Quantile(weightedExpanded, percentile, QuantileType1)
+
+		if err != nil {
+			t.Errorf("Error: %v", err)
+		}
+
+		if result != expected {
+			t.Errorf("p = %v\nExpected result of:\n%v\ngot result:\n%v", percentile, expected, result)
+		}
+	}
+
+	// values with zero weight are never returned
+	result, _ := WeightedQuantile([]float64{-100, 1, 2, 100}, []float64{0, 1, 1, 0}, 1)
+	expected := 2.0
+
+	if result != expected {
+		t.Errorf("Expected result of:\n%v\ngot result:\n%v", expected, result)
+	}
+
+	_, err := WeightedQuantile(weightedValues, weightedCounts, -0.1) // test the percentile range logic
+
+	if err == nil {
+		t.Errorf("Function accepted a negative percentile")
+	}
+}